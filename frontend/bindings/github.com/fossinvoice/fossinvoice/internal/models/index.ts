@@ -2,10 +2,32 @@
 // This file is automatically generated. DO NOT EDIT
 
 export {
+    Attachment,
+    BankAccount,
+    CatalogItem,
     Client,
+    ClientAddress,
+    ClientContact,
+    ClientDefaults,
     Company,
     CompanyDefaults,
     ContactInfo,
+    ExchangeRate,
+    Expense,
     Invoice,
-    InvoiceItem
+    InvoiceDefaults,
+    InvoiceItem,
+    InvoiceTaxLine,
+    NumberingSeries,
+    Payment,
+    PaymentTerms,
+    PostalAddress,
+    RecurringInvoice,
+    RecurringInvoiceItem,
+    TimeEntry
+} from "./models.js";
+
+export type {
+    Decimal,
+    Rate
 } from "./models.js";
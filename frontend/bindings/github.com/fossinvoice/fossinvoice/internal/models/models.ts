@@ -12,6 +12,254 @@ import * as gorm$0 from "../../../../../gorm.io/gorm/models.js";
 // @ts-ignore: Unused imports
 import * as time$0 from "../../../../../time/models.js";
 
+/**
+ * Attachment is a file kept with an invoice, e.g. a signed order, a timesheet or a delivery receipt.
+ * The content is stored in the database next to its SHA-256 digest, which is checked when the file is
+ * extracted again.
+ */
+export class Attachment {
+    "ID": number;
+    "CreatedAt": time$0.Time;
+    "UpdatedAt": time$0.Time;
+    "DeletedAt": gorm$0.DeletedAt;
+    "InvoiceID": number;
+
+    /**
+     * base name of the original file
+     */
+    "FileName": string;
+    "MimeType": string;
+    "Size": number;
+
+    /**
+     * hex-encoded digest of Data
+     */
+    "SHA256": string;
+
+    /** Creates a new Attachment instance. */
+    constructor($$source: Partial<Attachment> = {}) {
+        if (!("ID" in $$source)) {
+            this["ID"] = 0;
+        }
+        if (!("CreatedAt" in $$source)) {
+            this["CreatedAt"] = null;
+        }
+        if (!("UpdatedAt" in $$source)) {
+            this["UpdatedAt"] = null;
+        }
+        if (!("DeletedAt" in $$source)) {
+            this["DeletedAt"] = null;
+        }
+        if (!("InvoiceID" in $$source)) {
+            this["InvoiceID"] = 0;
+        }
+        if (!("FileName" in $$source)) {
+            this["FileName"] = "";
+        }
+        if (!("MimeType" in $$source)) {
+            this["MimeType"] = "";
+        }
+        if (!("Size" in $$source)) {
+            this["Size"] = 0;
+        }
+        if (!("SHA256" in $$source)) {
+            this["SHA256"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new Attachment instance from a string or object.
+     */
+    static createFrom($$source: any = {}): Attachment {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new Attachment($$parsedSource as Partial<Attachment>);
+    }
+}
+
+/**
+ * BankAccount is an account of a company that clients pay into, printed in the "Payment details"
+ * block of invoices. Accounts outside IBAN countries leave IBAN empty and use Details instead.
+ */
+export class BankAccount {
+    "ID": number;
+    "CreatedAt": time$0.Time;
+    "UpdatedAt": time$0.Time;
+    "DeletedAt": gorm$0.DeletedAt;
+    "CompanyID": number;
+
+    /**
+     * label shown when picking the account, e.g. "Main EUR account"
+     */
+    "Name": string;
+
+    /**
+     * printed as beneficiary; empty uses the company name
+     */
+    "AccountHolder": string;
+    "BankName": string;
+
+    /**
+     * stored normalized: upper case without spaces
+     */
+    "IBAN": string;
+
+    /**
+     * 8 or 11 characters, upper case
+     */
+    "BIC": string;
+
+    /**
+     * other payment instructions, e.g. account and routing number
+     */
+    "Details": string;
+
+    /** Creates a new BankAccount instance. */
+    constructor($$source: Partial<BankAccount> = {}) {
+        if (!("ID" in $$source)) {
+            this["ID"] = 0;
+        }
+        if (!("CreatedAt" in $$source)) {
+            this["CreatedAt"] = null;
+        }
+        if (!("UpdatedAt" in $$source)) {
+            this["UpdatedAt"] = null;
+        }
+        if (!("DeletedAt" in $$source)) {
+            this["DeletedAt"] = null;
+        }
+        if (!("CompanyID" in $$source)) {
+            this["CompanyID"] = 0;
+        }
+        if (!("Name" in $$source)) {
+            this["Name"] = "";
+        }
+        if (!("AccountHolder" in $$source)) {
+            this["AccountHolder"] = "";
+        }
+        if (!("BankName" in $$source)) {
+            this["BankName"] = "";
+        }
+        if (!("IBAN" in $$source)) {
+            this["IBAN"] = "";
+        }
+        if (!("BIC" in $$source)) {
+            this["BIC"] = "";
+        }
+        if (!("Details" in $$source)) {
+            this["Details"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new BankAccount instance from a string or object.
+     */
+    static createFrom($$source: any = {}): BankAccount {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new BankAccount($$parsedSource as Partial<BankAccount>);
+    }
+}
+
+/**
+ * CatalogItem is a product or service a company sells, used to prefill invoice lines.
+ * Invoice items copy its values when added, so later catalog changes never alter issued documents.
+ */
+export class CatalogItem {
+    "ID": number;
+    "CreatedAt": time$0.Time;
+    "UpdatedAt": time$0.Time;
+    "DeletedAt": gorm$0.DeletedAt;
+    "CompanyID": number;
+
+    /**
+     * optional SKU / internal code, unique per company when set
+     */
+    "Code": string;
+
+    /**
+     * short name, e.g. "Consulting hour"
+     */
+    "Name": string;
+
+    /**
+     * text copied to the invoice line; Name is used when empty
+     */
+    "Description": string;
+
+    /**
+     * unit of sale (UN/ECE Rec 20 code, see StandardUnits), e.g. UnitHour
+     */
+    "Unit": string;
+
+    /**
+     * unit price copied to new invoice lines
+     */
+    "DefaultPrice": Decimal;
+
+    /**
+     * percentage; nil uses the invoice TaxRate
+     */
+    "DefaultTaxRate": Decimal | null;
+
+    /**
+     * inactive entries are hidden from search but kept for history
+     */
+    "Active": boolean;
+
+    /** Creates a new CatalogItem instance. */
+    constructor($$source: Partial<CatalogItem> = {}) {
+        if (!("ID" in $$source)) {
+            this["ID"] = 0;
+        }
+        if (!("CreatedAt" in $$source)) {
+            this["CreatedAt"] = null;
+        }
+        if (!("UpdatedAt" in $$source)) {
+            this["UpdatedAt"] = null;
+        }
+        if (!("DeletedAt" in $$source)) {
+            this["DeletedAt"] = null;
+        }
+        if (!("CompanyID" in $$source)) {
+            this["CompanyID"] = 0;
+        }
+        if (!("Code" in $$source)) {
+            this["Code"] = "";
+        }
+        if (!("Name" in $$source)) {
+            this["Name"] = "";
+        }
+        if (!("Description" in $$source)) {
+            this["Description"] = "";
+        }
+        if (!("Unit" in $$source)) {
+            this["Unit"] = "";
+        }
+        if (!("DefaultPrice" in $$source)) {
+            this["DefaultPrice"] = null;
+        }
+        if (!("DefaultTaxRate" in $$source)) {
+            this["DefaultTaxRate"] = null;
+        }
+        if (!("Active" in $$source)) {
+            this["Active"] = false;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new CatalogItem instance from a string or object.
+     */
+    static createFrom($$source: any = {}): CatalogItem {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new CatalogItem($$parsedSource as Partial<CatalogItem>);
+    }
+}
+
 /**
  * Client represents the buyer.
  */
@@ -26,13 +274,19 @@ export class Client {
      */
     "CompanyID": number;
     "Name": string;
-    "Address": string;
+    "Address": PostalAddress;
     "TaxID": string;
 
     /**
      * Inline contact fields for simplicity
      */
     "Contact": ContactInfo;
+
+    /**
+     * Additional contacts and addresses by role (billing, accounts payable, shipping, ...)
+     */
+    "Contacts": ClientContact[];
+    "Addresses": ClientAddress[];
     "Invoices": Invoice[];
 
     /** Creates a new Client instance. */
@@ -56,7 +310,7 @@ export class Client {
             this["Name"] = "";
         }
         if (!("Address" in $$source)) {
-            this["Address"] = "";
+            this["Address"] = (new PostalAddress());
         }
         if (!("TaxID" in $$source)) {
             this["TaxID"] = "";
@@ -64,6 +318,12 @@ export class Client {
         if (!("Contact" in $$source)) {
             this["Contact"] = (new ContactInfo());
         }
+        if (!("Contacts" in $$source)) {
+            this["Contacts"] = [];
+        }
+        if (!("Addresses" in $$source)) {
+            this["Addresses"] = [];
+        }
         if (!("Invoices" in $$source)) {
             this["Invoices"] = [];
         }
@@ -75,45 +335,59 @@ export class Client {
      * Creates a new Client instance from a string or object.
      */
     static createFrom($$source: any = {}): Client {
-        const $$createField8_0 = $$createType0;
-        const $$createField9_0 = $$createType2;
+        const $$createField6_0 = $$createType0;
+        const $$createField8_0 = $$createType1;
+        const $$createField9_0 = $$createType3;
+        const $$createField10_0 = $$createType5;
+        const $$createField11_0 = $$createType7;
         let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("Address" in $$parsedSource) {
+            $$parsedSource["Address"] = $$createField6_0($$parsedSource["Address"]);
+        }
         if ("Contact" in $$parsedSource) {
             $$parsedSource["Contact"] = $$createField8_0($$parsedSource["Contact"]);
         }
+        if ("Contacts" in $$parsedSource) {
+            $$parsedSource["Contacts"] = $$createField9_0($$parsedSource["Contacts"]);
+        }
+        if ("Addresses" in $$parsedSource) {
+            $$parsedSource["Addresses"] = $$createField10_0($$parsedSource["Addresses"]);
+        }
         if ("Invoices" in $$parsedSource) {
-            $$parsedSource["Invoices"] = $$createField9_0($$parsedSource["Invoices"]);
+            $$parsedSource["Invoices"] = $$createField11_0($$parsedSource["Invoices"]);
         }
         return new Client($$parsedSource as Partial<Client>);
     }
 }
 
 /**
- * Company represents the seller.
+ * ClientAddress is an additional postal address of a client, such as a separate billing or shipping address.
  */
-export class Company {
+export class ClientAddress {
     "ID": number;
     "CreatedAt": time$0.Time;
     "UpdatedAt": time$0.Time;
     "DeletedAt": gorm$0.DeletedAt;
-    "Name": string;
-    "Address": string;
-    "TaxID": string;
-    "IconB64": string;
+    "ClientID": number;
 
     /**
-     * Inline contact fields into the same table for simplicity
+     * one of the AddressRole* constants
      */
-    "Contact": ContactInfo;
+    "Role": string;
 
     /**
-     * Relations
+     * optional name shown when picking the address, e.g. "Head office"
      */
-    "Clients": Client[];
-    "Invoices": Invoice[];
+    "Label": string;
+    "Address": PostalAddress;
 
-    /** Creates a new Company instance. */
-    constructor($$source: Partial<Company> = {}) {
+    /**
+     * used for new invoices when none is picked (one per client and role)
+     */
+    "IsDefault": boolean;
+
+    /** Creates a new ClientAddress instance. */
+    constructor($$source: Partial<ClientAddress> = {}) {
         if (!("ID" in $$source)) {
             this["ID"] = 0;
         }
@@ -126,76 +400,1368 @@ export class Company {
         if (!("DeletedAt" in $$source)) {
             this["DeletedAt"] = null;
         }
-        if (!("Name" in $$source)) {
-            this["Name"] = "";
-        }
-        if (!("Address" in $$source)) {
-            this["Address"] = "";
-        }
-        if (!("TaxID" in $$source)) {
-            this["TaxID"] = "";
+        if (!("ClientID" in $$source)) {
+            this["ClientID"] = 0;
         }
-        if (!("IconB64" in $$source)) {
-            this["IconB64"] = "";
+        if (!("Role" in $$source)) {
+            this["Role"] = "";
         }
-        if (!("Contact" in $$source)) {
-            this["Contact"] = (new ContactInfo());
+        if (!("Label" in $$source)) {
+            this["Label"] = "";
         }
-        if (!("Clients" in $$source)) {
-            this["Clients"] = [];
+        if (!("Address" in $$source)) {
+            this["Address"] = (new PostalAddress());
         }
-        if (!("Invoices" in $$source)) {
-            this["Invoices"] = [];
+        if (!("IsDefault" in $$source)) {
+            this["IsDefault"] = false;
         }
 
         Object.assign(this, $$source);
     }
 
     /**
-     * Creates a new Company instance from a string or object.
+     * Creates a new ClientAddress instance from a string or object.
      */
-    static createFrom($$source: any = {}): Company {
-        const $$createField8_0 = $$createType0;
-        const $$createField9_0 = $$createType4;
-        const $$createField10_0 = $$createType2;
+    static createFrom($$source: any = {}): ClientAddress {
+        const $$createField7_0 = $$createType0;
         let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
-        if ("Contact" in $$parsedSource) {
-            $$parsedSource["Contact"] = $$createField8_0($$parsedSource["Contact"]);
+        if ("Address" in $$parsedSource) {
+            $$parsedSource["Address"] = $$createField7_0($$parsedSource["Address"]);
         }
-        if ("Clients" in $$parsedSource) {
-            $$parsedSource["Clients"] = $$createField9_0($$parsedSource["Clients"]);
+        return new ClientAddress($$parsedSource as Partial<ClientAddress>);
+    }
+}
+
+/**
+ * ClientContact is an additional contact person or mailbox of a client. The contact embedded in
+ * Client remains the general one; these records allow one per purpose.
+ */
+export class ClientContact {
+    "ID": number;
+    "CreatedAt": time$0.Time;
+    "UpdatedAt": time$0.Time;
+    "DeletedAt": gorm$0.DeletedAt;
+    "ClientID": number;
+
+    /**
+     * one of the ContactRole* constants
+     */
+    "Role": string;
+
+    /**
+     * person or department, e.g. "Accounts payable"
+     */
+    "Name": string;
+    "Contact": ContactInfo;
+
+    /**
+     * used for new invoices when none is picked (one per client and role)
+     */
+    "IsDefault": boolean;
+
+    /** Creates a new ClientContact instance. */
+    constructor($$source: Partial<ClientContact> = {}) {
+        if (!("ID" in $$source)) {
+            this["ID"] = 0;
         }
-        if ("Invoices" in $$parsedSource) {
-            $$parsedSource["Invoices"] = $$createField10_0($$parsedSource["Invoices"]);
+        if (!("CreatedAt" in $$source)) {
+            this["CreatedAt"] = null;
         }
-        return new Company($$parsedSource as Partial<Company>);
+        if (!("UpdatedAt" in $$source)) {
+            this["UpdatedAt"] = null;
+        }
+        if (!("DeletedAt" in $$source)) {
+            this["DeletedAt"] = null;
+        }
+        if (!("ClientID" in $$source)) {
+            this["ClientID"] = 0;
+        }
+        if (!("Role" in $$source)) {
+            this["Role"] = "";
+        }
+        if (!("Name" in $$source)) {
+            this["Name"] = "";
+        }
+        if (!("Contact" in $$source)) {
+            this["Contact"] = (new ContactInfo());
+        }
+        if (!("IsDefault" in $$source)) {
+            this["IsDefault"] = false;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ClientContact instance from a string or object.
+     */
+    static createFrom($$source: any = {}): ClientContact {
+        const $$createField7_0 = $$createType1;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("Contact" in $$parsedSource) {
+            $$parsedSource["Contact"] = $$createField7_0($$parsedSource["Contact"]);
+        }
+        return new ClientContact($$parsedSource as Partial<ClientContact>);
+    }
+}
+
+/**
+ * ClientDefaults optionally overrides the company defaults for one client (one-to-one), e.g. a
+ * foreign client invoiced in another currency, at a 0% reverse-charge rate and in its own language.
+ * Nil or empty fields inherit the company value.
+ */
+export class ClientDefaults {
+    "ID": number;
+    "CreatedAt": time$0.Time;
+    "UpdatedAt": time$0.Time;
+    "DeletedAt": gorm$0.DeletedAt;
+    "ClientID": number;
+
+    /**
+     * ISO 4217 code; empty inherits
+     */
+    "Currency": string;
+
+    /**
+     * percentage; nil inherits, 0 for reverse charge / exempt
+     */
+    "TaxRate": Decimal | null;
+
+    /**
+     * percentage; nil inherits
+     */
+    "WithholdingRate": Decimal | null;
+
+    /**
+     * zero inherits
+     */
+    "PaymentTerms": PaymentTerms;
+
+    /**
+     * document language (BCP47, e.g. "es"); empty uses the application language
+     */
+    "Language": string;
+
+    /**
+     * nil inherits, "" prints no footer
+     */
+    "FooterText": string | null;
+
+    /** Creates a new ClientDefaults instance. */
+    constructor($$source: Partial<ClientDefaults> = {}) {
+        if (!("ID" in $$source)) {
+            this["ID"] = 0;
+        }
+        if (!("CreatedAt" in $$source)) {
+            this["CreatedAt"] = null;
+        }
+        if (!("UpdatedAt" in $$source)) {
+            this["UpdatedAt"] = null;
+        }
+        if (!("DeletedAt" in $$source)) {
+            this["DeletedAt"] = null;
+        }
+        if (!("ClientID" in $$source)) {
+            this["ClientID"] = 0;
+        }
+        if (!("Currency" in $$source)) {
+            this["Currency"] = "";
+        }
+        if (!("TaxRate" in $$source)) {
+            this["TaxRate"] = null;
+        }
+        if (!("WithholdingRate" in $$source)) {
+            this["WithholdingRate"] = null;
+        }
+        if (!("PaymentTerms" in $$source)) {
+            this["PaymentTerms"] = (new PaymentTerms());
+        }
+        if (!("Language" in $$source)) {
+            this["Language"] = "";
+        }
+        if (!("FooterText" in $$source)) {
+            this["FooterText"] = null;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ClientDefaults instance from a string or object.
+     */
+    static createFrom($$source: any = {}): ClientDefaults {
+        const $$createField8_0 = $$createType8;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("PaymentTerms" in $$parsedSource) {
+            $$parsedSource["PaymentTerms"] = $$createField8_0($$parsedSource["PaymentTerms"]);
+        }
+        return new ClientDefaults($$parsedSource as Partial<ClientDefaults>);
+    }
+}
+
+/**
+ * Company represents the seller.
+ */
+export class Company {
+    "ID": number;
+    "CreatedAt": time$0.Time;
+    "UpdatedAt": time$0.Time;
+    "DeletedAt": gorm$0.DeletedAt;
+    "Name": string;
+    "Address": PostalAddress;
+    "TaxID": string;
+    "IconB64": string;
+
+    /**
+     * Inline contact fields into the same table for simplicity
+     */
+    "Contact": ContactInfo;
+
+    /**
+     * Relations
+     */
+    "Clients": Client[];
+    "Invoices": Invoice[];
+
+    /** Creates a new Company instance. */
+    constructor($$source: Partial<Company> = {}) {
+        if (!("ID" in $$source)) {
+            this["ID"] = 0;
+        }
+        if (!("CreatedAt" in $$source)) {
+            this["CreatedAt"] = null;
+        }
+        if (!("UpdatedAt" in $$source)) {
+            this["UpdatedAt"] = null;
+        }
+        if (!("DeletedAt" in $$source)) {
+            this["DeletedAt"] = null;
+        }
+        if (!("Name" in $$source)) {
+            this["Name"] = "";
+        }
+        if (!("Address" in $$source)) {
+            this["Address"] = (new PostalAddress());
+        }
+        if (!("TaxID" in $$source)) {
+            this["TaxID"] = "";
+        }
+        if (!("IconB64" in $$source)) {
+            this["IconB64"] = "";
+        }
+        if (!("Contact" in $$source)) {
+            this["Contact"] = (new ContactInfo());
+        }
+        if (!("Clients" in $$source)) {
+            this["Clients"] = [];
+        }
+        if (!("Invoices" in $$source)) {
+            this["Invoices"] = [];
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new Company instance from a string or object.
+     */
+    static createFrom($$source: any = {}): Company {
+        const $$createField5_0 = $$createType0;
+        const $$createField8_0 = $$createType1;
+        const $$createField9_0 = $$createType10;
+        const $$createField10_0 = $$createType7;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("Address" in $$parsedSource) {
+            $$parsedSource["Address"] = $$createField5_0($$parsedSource["Address"]);
+        }
+        if ("Contact" in $$parsedSource) {
+            $$parsedSource["Contact"] = $$createField8_0($$parsedSource["Contact"]);
+        }
+        if ("Clients" in $$parsedSource) {
+            $$parsedSource["Clients"] = $$createField9_0($$parsedSource["Clients"]);
+        }
+        if ("Invoices" in $$parsedSource) {
+            $$parsedSource["Invoices"] = $$createField10_0($$parsedSource["Invoices"]);
+        }
+        return new Company($$parsedSource as Partial<Company>);
+    }
+}
+
+/**
+ * CompanyDefaults stores default configuration for a company (one-to-one).
+ */
+export class CompanyDefaults {
+    "ID": number;
+    "CreatedAt": time$0.Time;
+    "UpdatedAt": time$0.Time;
+    "DeletedAt": gorm$0.DeletedAt;
+    "CompanyID": number;
+    "Company": Company;
+
+    /**
+     * ISO 4217 code e.g. "USD", "EUR"
+     */
+    "DefaultCurrency": string;
+
+    /**
+     * percentage, e.g., 21.0
+     */
+    "DefaultTaxRate": Decimal;
+
+    /**
+     * IRPF / ritenuta d'acconto percentage, 0 if not applicable
+     */
+    "DefaultWithholdingRate": Decimal;
+    "DefaultFooterText": string;
+
+    /**
+     * zero for none
+     */
+    "DefaultPaymentTerms": PaymentTerms;
+
+    /**
+     * bank account printed on new invoices; nil for none
+     */
+    "DefaultBankAccountID": number | null;
+
+    /** Creates a new CompanyDefaults instance. */
+    constructor($$source: Partial<CompanyDefaults> = {}) {
+        if (!("ID" in $$source)) {
+            this["ID"] = 0;
+        }
+        if (!("CreatedAt" in $$source)) {
+            this["CreatedAt"] = null;
+        }
+        if (!("UpdatedAt" in $$source)) {
+            this["UpdatedAt"] = null;
+        }
+        if (!("DeletedAt" in $$source)) {
+            this["DeletedAt"] = null;
+        }
+        if (!("CompanyID" in $$source)) {
+            this["CompanyID"] = 0;
+        }
+        if (!("Company" in $$source)) {
+            this["Company"] = (new Company());
+        }
+        if (!("DefaultCurrency" in $$source)) {
+            this["DefaultCurrency"] = "";
+        }
+        if (!("DefaultTaxRate" in $$source)) {
+            this["DefaultTaxRate"] = null;
+        }
+        if (!("DefaultWithholdingRate" in $$source)) {
+            this["DefaultWithholdingRate"] = null;
+        }
+        if (!("DefaultFooterText" in $$source)) {
+            this["DefaultFooterText"] = "";
+        }
+        if (!("DefaultPaymentTerms" in $$source)) {
+            this["DefaultPaymentTerms"] = (new PaymentTerms());
+        }
+        if (!("DefaultBankAccountID" in $$source)) {
+            this["DefaultBankAccountID"] = null;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new CompanyDefaults instance from a string or object.
+     */
+    static createFrom($$source: any = {}): CompanyDefaults {
+        const $$createField5_0 = $$createType11;
+        const $$createField10_0 = $$createType8;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("Company" in $$parsedSource) {
+            $$parsedSource["Company"] = $$createField5_0($$parsedSource["Company"]);
+        }
+        if ("DefaultPaymentTerms" in $$parsedSource) {
+            $$parsedSource["DefaultPaymentTerms"] = $$createField10_0($$parsedSource["DefaultPaymentTerms"]);
+        }
+        return new CompanyDefaults($$parsedSource as Partial<CompanyDefaults>);
+    }
+}
+
+/**
+ * ContactInfo is embedded into Company and Client tables.
+ */
+export class ContactInfo {
+    "Email": string | null;
+    "Phone": string | null;
+    "Website": string | null;
+
+    /** Creates a new ContactInfo instance. */
+    constructor($$source: Partial<ContactInfo> = {}) {
+        if (!("Email" in $$source)) {
+            this["Email"] = null;
+        }
+        if (!("Phone" in $$source)) {
+            this["Phone"] = null;
+        }
+        if (!("Website" in $$source)) {
+            this["Website"] = null;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ContactInfo instance from a string or object.
+     */
+    static createFrom($$source: any = {}): ContactInfo {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new ContactInfo($$parsedSource as Partial<ContactInfo>);
+    }
+}
+
+/**
+ * Decimal is an exact fixed-point number with four fractional digits, stored as a
+ * scaled integer (e.g. 12.5 is Decimal(125000)). It is used for amounts, quantities
+ * and rates so that sums never drift. In the database it is an INTEGER column, and
+ * in JSON it is a plain number so the frontend keeps working with numbers.
+ */
+export type Decimal = any;
+
+/**
+ * ExchangeRate is the price of one unit of BaseCurrency in Currency on a day, quoted like the ECB
+ * reference rates: BaseCurrency EUR, Currency USD, Rate 1.0812 means 1 EUR = 1.0812 USD.
+ * There is at most one rate per day and currency pair; importing again replaces it.
+ */
+export class ExchangeRate {
+    "ID": number;
+    "CreatedAt": time$0.Time;
+    "UpdatedAt": time$0.Time;
+    "DeletedAt": gorm$0.DeletedAt;
+
+    /**
+     * ISO date (YYYY-MM-DD)
+     */
+    "Date": string;
+
+    /**
+     * ISO 4217 code, e.g. "EUR"
+     */
+    "BaseCurrency": string;
+
+    /**
+     * ISO 4217 code, e.g. "USD"
+     */
+    "Currency": string;
+
+    /**
+     * units of Currency per unit of BaseCurrency
+     */
+    "Rate": Rate;
+
+    /**
+     * RateSourceManual or RateSourceECB
+     */
+    "Source": string;
+
+    /** Creates a new ExchangeRate instance. */
+    constructor($$source: Partial<ExchangeRate> = {}) {
+        if (!("ID" in $$source)) {
+            this["ID"] = 0;
+        }
+        if (!("CreatedAt" in $$source)) {
+            this["CreatedAt"] = null;
+        }
+        if (!("UpdatedAt" in $$source)) {
+            this["UpdatedAt"] = null;
+        }
+        if (!("DeletedAt" in $$source)) {
+            this["DeletedAt"] = null;
+        }
+        if (!("Date" in $$source)) {
+            this["Date"] = "";
+        }
+        if (!("BaseCurrency" in $$source)) {
+            this["BaseCurrency"] = "";
+        }
+        if (!("Currency" in $$source)) {
+            this["Currency"] = "";
+        }
+        if (!("Rate" in $$source)) {
+            this["Rate"] = null;
+        }
+        if (!("Source" in $$source)) {
+            this["Source"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ExchangeRate instance from a string or object.
+     */
+    static createFrom($$source: any = {}): ExchangeRate {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new ExchangeRate($$parsedSource as Partial<ExchangeRate>);
+    }
+}
+
+/**
+ * Expense is a cost of a company, e.g. a supplier invoice or a till receipt. Amounts are in Currency;
+ * reports convert them to the company's base currency at the rate of Date.
+ */
+export class Expense {
+    "ID": number;
+    "CreatedAt": time$0.Time;
+    "UpdatedAt": time$0.Time;
+    "DeletedAt": gorm$0.DeletedAt;
+    "CompanyID": number;
+
+    /**
+     * ISO date of the supplier document
+     */
+    "Date": string;
+
+    /**
+     * defaults to the year of Date
+     */
+    "FiscalYear": number;
+    "Supplier": string;
+
+    /**
+     * free text, e.g. "Travel" or "Software"
+     */
+    "Category": string;
+    "Description": string;
+
+    /**
+     * amount before tax
+     */
+    "Net": Decimal;
+
+    /**
+     * tax paid, deducted from the tax collected in reports
+     */
+    "Tax": Decimal;
+
+    /**
+     * Net + Tax
+     */
+    "Total": Decimal;
+
+    /**
+     * ISO 4217 code
+     */
+    "Currency": string;
+
+    /**
+     * Receipt attachment (scan or PDF of the supplier document). The content is only loaded by
+     * ExportExpenseReceipt, never sent along with the expense.
+     */
+    "ReceiptName": string;
+    "ReceiptMimeType": string;
+
+    /** Creates a new Expense instance. */
+    constructor($$source: Partial<Expense> = {}) {
+        if (!("ID" in $$source)) {
+            this["ID"] = 0;
+        }
+        if (!("CreatedAt" in $$source)) {
+            this["CreatedAt"] = null;
+        }
+        if (!("UpdatedAt" in $$source)) {
+            this["UpdatedAt"] = null;
+        }
+        if (!("DeletedAt" in $$source)) {
+            this["DeletedAt"] = null;
+        }
+        if (!("CompanyID" in $$source)) {
+            this["CompanyID"] = 0;
+        }
+        if (!("Date" in $$source)) {
+            this["Date"] = "";
+        }
+        if (!("FiscalYear" in $$source)) {
+            this["FiscalYear"] = 0;
+        }
+        if (!("Supplier" in $$source)) {
+            this["Supplier"] = "";
+        }
+        if (!("Category" in $$source)) {
+            this["Category"] = "";
+        }
+        if (!("Description" in $$source)) {
+            this["Description"] = "";
+        }
+        if (!("Net" in $$source)) {
+            this["Net"] = null;
+        }
+        if (!("Tax" in $$source)) {
+            this["Tax"] = null;
+        }
+        if (!("Total" in $$source)) {
+            this["Total"] = null;
+        }
+        if (!("Currency" in $$source)) {
+            this["Currency"] = "";
+        }
+        if (!("ReceiptName" in $$source)) {
+            this["ReceiptName"] = "";
+        }
+        if (!("ReceiptMimeType" in $$source)) {
+            this["ReceiptMimeType"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new Expense instance from a string or object.
+     */
+    static createFrom($$source: any = {}): Expense {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new Expense($$parsedSource as Partial<Expense>);
+    }
+}
+
+export class Invoice {
+    "ID": number;
+    "CreatedAt": time$0.Time;
+    "UpdatedAt": time$0.Time;
+    "DeletedAt": gorm$0.DeletedAt;
+
+    /**
+     * Ownership / FKs
+     */
+    "CompanyID": number;
+    "ClientID": number;
+    "Company": Company;
+    "Client": Client;
+
+    /**
+     * Client contact and address the document is addressed to; see ClientContact and ClientAddress.
+     * When nil, the PDF prints the contact and address stored on the client itself.
+     */
+    "BillingContactID": number | null;
+    "BillingAddressID": number | null;
+
+    /**
+     * Company bank account printed in the payment details; the company default when not picked
+     */
+    "BankAccountID": number | null;
+
+    /**
+     * Document kind; credit notes reference the invoice they correct and carry negative amounts
+     * one of the DocumentType* constants
+     */
+    "DocumentType": string;
+
+    /**
+     * credited invoice, set only for credit notes
+     */
+    "OriginalInvoiceID": number | null;
+
+    /**
+     * quote an invoice was converted from
+     */
+    "QuoteID": number | null;
+
+    /**
+     * Identification & dates
+     * numbering series the number was allocated from; nil when numbered manually
+     */
+    "SeriesID": number | null;
+
+    /**
+     * sequence number within the series (or the manual number)
+     */
+    "Number": number;
+
+    /**
+     * formatted number printed on the document, e.g. "INV-2025-0001"; empty for drafts not numbered yet
+     */
+    "DisplayNumber": string;
+
+    /**
+     * ISO date (YYYY-MM-DD)
+     */
+    "IssueDate": string;
+
+    /**
+     * ISO date (YYYY-MM-DD)
+     */
+    "DueDate": string;
+
+    /**
+     * quotes only: ISO date until which the quote can be accepted
+     */
+    "ValidUntil": string;
+
+    /**
+     * DueDate is computed from them when set
+     */
+    "PaymentTerms": PaymentTerms;
+
+    /**
+     * Fiscal categorization
+     * e.g., 2025
+     */
+    "FiscalYear": number;
+
+    /**
+     * Currency & amounts
+     * ISO 4217 code, e.g. "USD", "EUR"
+     */
+    "Currency": string;
+
+    /**
+     * sum of item totals (after line discounts) before invoice discount and tax
+     */
+    "Subtotal": Decimal;
+
+    /**
+     * default percentage for items without their own rate, e.g. 21.0 for 21%
+     */
+    "TaxRate": Decimal;
+
+    /**
+     * sum of the tax amounts in TaxLines
+     */
+    "TaxAmount": Decimal;
+
+    /**
+     * optional invoice-level percentage discount; when set, DiscountAmount is derived from it
+     */
+    "DiscountRate": Decimal;
+
+    /**
+     * invoice-level absolute discount, applied before tax
+     */
+    "DiscountAmount": Decimal;
+
+    /**
+     * Withholding (e.g. Spanish IRPF, Italian ritenuta d'acconto) deducted from the taxable base
+     * percentage, e.g. 15.0 for 15%
+     */
+    "WithholdingRate": Decimal;
+
+    /**
+     * computed amount withheld by the client
+     */
+    "WithholdingAmount": Decimal;
+
+    /**
+     * amount payable after tax, discounts and withholding
+     */
+    "Total": Decimal;
+
+    /**
+     * sum of non-voided payments, maintained by the payments ledger
+     */
+    "AmountPaid": Decimal;
+
+    /**
+     * sum of the issued credit notes against the invoice, as a positive amount
+     */
+    "AmountCredited": Decimal;
+
+    /**
+     * Exchange rate snapshot taken while the invoice is a Draft, frozen once it is issued
+     * company default currency at that time
+     */
+    "BaseCurrency": string;
+
+    /**
+     * units of Currency per unit of BaseCurrency; 1 for the same currency, 0 if unknown
+     */
+    "ExchangeRate": Rate;
+
+    /**
+     * ExchangeRate was entered by hand and is not refreshed from the stored rates
+     */
+    "ExchangeRateManual": boolean;
+
+    /**
+     * Status & presentation
+     * one of the Status* constants; Paid/PartiallyPaid are set automatically from payments
+     */
+    "Status": string;
+
+    /**
+     * optional footer/notes to show on the PDF
+     */
+    "Notes": string | null;
+
+    /**
+     * document language (BCP47) taken from the client defaults; empty uses the export language
+     */
+    "Language": string;
+
+    /**
+     * Overdue state, derived from DueDate when invoices are loaded (not stored)
+     */
+    "IsOverdue": boolean;
+
+    /**
+     * days past DueDate, 0 when not overdue
+     */
+    "DaysOverdue": number;
+
+    /**
+     * Footer text printed at the bottom of the invoice PDF
+     */
+    "FooterText": string;
+
+    /**
+     * Lines
+     */
+    "Items": InvoiceItem[];
+
+    /**
+     * Per-rate tax breakdown, derived from the lines by ComputeTotals
+     */
+    "TaxLines": InvoiceTaxLine[];
+
+    /**
+     * Payments received against the invoice
+     */
+    "Payments": Payment[];
+
+    /** Creates a new Invoice instance. */
+    constructor($$source: Partial<Invoice> = {}) {
+        if (!("ID" in $$source)) {
+            this["ID"] = 0;
+        }
+        if (!("CreatedAt" in $$source)) {
+            this["CreatedAt"] = null;
+        }
+        if (!("UpdatedAt" in $$source)) {
+            this["UpdatedAt"] = null;
+        }
+        if (!("DeletedAt" in $$source)) {
+            this["DeletedAt"] = null;
+        }
+        if (!("CompanyID" in $$source)) {
+            this["CompanyID"] = 0;
+        }
+        if (!("ClientID" in $$source)) {
+            this["ClientID"] = 0;
+        }
+        if (!("Company" in $$source)) {
+            this["Company"] = (new Company());
+        }
+        if (!("Client" in $$source)) {
+            this["Client"] = (new Client());
+        }
+        if (!("BillingContactID" in $$source)) {
+            this["BillingContactID"] = null;
+        }
+        if (!("BillingAddressID" in $$source)) {
+            this["BillingAddressID"] = null;
+        }
+        if (!("BankAccountID" in $$source)) {
+            this["BankAccountID"] = null;
+        }
+        if (!("DocumentType" in $$source)) {
+            this["DocumentType"] = "";
+        }
+        if (!("OriginalInvoiceID" in $$source)) {
+            this["OriginalInvoiceID"] = null;
+        }
+        if (!("QuoteID" in $$source)) {
+            this["QuoteID"] = null;
+        }
+        if (!("SeriesID" in $$source)) {
+            this["SeriesID"] = null;
+        }
+        if (!("Number" in $$source)) {
+            this["Number"] = 0;
+        }
+        if (!("DisplayNumber" in $$source)) {
+            this["DisplayNumber"] = "";
+        }
+        if (!("IssueDate" in $$source)) {
+            this["IssueDate"] = "";
+        }
+        if (!("DueDate" in $$source)) {
+            this["DueDate"] = "";
+        }
+        if (!("ValidUntil" in $$source)) {
+            this["ValidUntil"] = "";
+        }
+        if (!("PaymentTerms" in $$source)) {
+            this["PaymentTerms"] = (new PaymentTerms());
+        }
+        if (!("FiscalYear" in $$source)) {
+            this["FiscalYear"] = 0;
+        }
+        if (!("Currency" in $$source)) {
+            this["Currency"] = "";
+        }
+        if (!("Subtotal" in $$source)) {
+            this["Subtotal"] = null;
+        }
+        if (!("TaxRate" in $$source)) {
+            this["TaxRate"] = null;
+        }
+        if (!("TaxAmount" in $$source)) {
+            this["TaxAmount"] = null;
+        }
+        if (!("DiscountRate" in $$source)) {
+            this["DiscountRate"] = null;
+        }
+        if (!("DiscountAmount" in $$source)) {
+            this["DiscountAmount"] = null;
+        }
+        if (!("WithholdingRate" in $$source)) {
+            this["WithholdingRate"] = null;
+        }
+        if (!("WithholdingAmount" in $$source)) {
+            this["WithholdingAmount"] = null;
+        }
+        if (!("Total" in $$source)) {
+            this["Total"] = null;
+        }
+        if (!("AmountPaid" in $$source)) {
+            this["AmountPaid"] = null;
+        }
+        if (!("AmountCredited" in $$source)) {
+            this["AmountCredited"] = null;
+        }
+        if (!("BaseCurrency" in $$source)) {
+            this["BaseCurrency"] = "";
+        }
+        if (!("ExchangeRate" in $$source)) {
+            this["ExchangeRate"] = null;
+        }
+        if (!("ExchangeRateManual" in $$source)) {
+            this["ExchangeRateManual"] = false;
+        }
+        if (!("Status" in $$source)) {
+            this["Status"] = "";
+        }
+        if (!("Notes" in $$source)) {
+            this["Notes"] = null;
+        }
+        if (!("Language" in $$source)) {
+            this["Language"] = "";
+        }
+        if (!("IsOverdue" in $$source)) {
+            this["IsOverdue"] = false;
+        }
+        if (!("DaysOverdue" in $$source)) {
+            this["DaysOverdue"] = 0;
+        }
+        if (!("FooterText" in $$source)) {
+            this["FooterText"] = "";
+        }
+        if (!("Items" in $$source)) {
+            this["Items"] = [];
+        }
+        if (!("TaxLines" in $$source)) {
+            this["TaxLines"] = [];
+        }
+        if (!("Payments" in $$source)) {
+            this["Payments"] = [];
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new Invoice instance from a string or object.
+     */
+    static createFrom($$source: any = {}): Invoice {
+        const $$createField6_0 = $$createType11;
+        const $$createField7_0 = $$createType9;
+        const $$createField20_0 = $$createType8;
+        const $$createField42_0 = $$createType13;
+        const $$createField43_0 = $$createType15;
+        const $$createField44_0 = $$createType17;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("Company" in $$parsedSource) {
+            $$parsedSource["Company"] = $$createField6_0($$parsedSource["Company"]);
+        }
+        if ("Client" in $$parsedSource) {
+            $$parsedSource["Client"] = $$createField7_0($$parsedSource["Client"]);
+        }
+        if ("PaymentTerms" in $$parsedSource) {
+            $$parsedSource["PaymentTerms"] = $$createField20_0($$parsedSource["PaymentTerms"]);
+        }
+        if ("Items" in $$parsedSource) {
+            $$parsedSource["Items"] = $$createField42_0($$parsedSource["Items"]);
+        }
+        if ("TaxLines" in $$parsedSource) {
+            $$parsedSource["TaxLines"] = $$createField43_0($$parsedSource["TaxLines"]);
+        }
+        if ("Payments" in $$parsedSource) {
+            $$parsedSource["Payments"] = $$createField44_0($$parsedSource["Payments"]);
+        }
+        return new Invoice($$parsedSource as Partial<Invoice>);
+    }
+}
+
+/**
+ * InvoiceDefaults are the values a new invoice for a client starts with: the company defaults
+ * with the client defaults merged on top.
+ */
+export class InvoiceDefaults {
+    "Currency": string;
+    "TaxRate": Decimal;
+    "WithholdingRate": Decimal;
+
+    /**
+     * zero when no terms are set
+     */
+    "PaymentTerms": PaymentTerms;
+
+    /**
+     * company bank account; nil for none
+     */
+    "BankAccountID": number | null;
+    "Language": string;
+    "FooterText": string;
+
+    /** Creates a new InvoiceDefaults instance. */
+    constructor($$source: Partial<InvoiceDefaults> = {}) {
+        if (!("Currency" in $$source)) {
+            this["Currency"] = "";
+        }
+        if (!("TaxRate" in $$source)) {
+            this["TaxRate"] = null;
+        }
+        if (!("WithholdingRate" in $$source)) {
+            this["WithholdingRate"] = null;
+        }
+        if (!("PaymentTerms" in $$source)) {
+            this["PaymentTerms"] = (new PaymentTerms());
+        }
+        if (!("BankAccountID" in $$source)) {
+            this["BankAccountID"] = null;
+        }
+        if (!("Language" in $$source)) {
+            this["Language"] = "";
+        }
+        if (!("FooterText" in $$source)) {
+            this["FooterText"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new InvoiceDefaults instance from a string or object.
+     */
+    static createFrom($$source: any = {}): InvoiceDefaults {
+        const $$createField3_0 = $$createType8;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("PaymentTerms" in $$parsedSource) {
+            $$parsedSource["PaymentTerms"] = $$createField3_0($$parsedSource["PaymentTerms"]);
+        }
+        return new InvoiceDefaults($$parsedSource as Partial<InvoiceDefaults>);
+    }
+}
+
+export class InvoiceItem {
+    "ID": number;
+    "CreatedAt": time$0.Time;
+    "UpdatedAt": time$0.Time;
+    "DeletedAt": gorm$0.DeletedAt;
+    "InvoiceID": number;
+
+    /**
+     * Catalog entry the line was created from; the fields below are a snapshot taken at that time
+     */
+    "CatalogItemID": number | null;
+
+    /**
+     * catalog code / SKU
+     */
+    "Code": string;
+    "Description": string;
+
+    /**
+     * supports fractional quantities (e.g., hours)
+     */
+    "Quantity": Decimal;
+
+    /**
+     * unit of measure (UN/ECE Rec 20 code, see StandardUnits); empty for none
+     */
+    "Unit": string;
+    "UnitPrice": Decimal;
+
+    /**
+     * percentage; nil uses the invoice TaxRate
+     */
+    "TaxRate": Decimal | null;
+
+    /**
+     * Line discount: a percentage, or an absolute amount when DiscountRate is zero
+     * percentage off Quantity * UnitPrice
+     */
+    "DiscountRate": Decimal;
+
+    /**
+     * absolute discount; derived from DiscountRate when that is set
+     */
+    "DiscountAmount": Decimal;
+
+    /**
+     * Quantity * UnitPrice - DiscountAmount
+     */
+    "Total": Decimal;
+
+    /** Creates a new InvoiceItem instance. */
+    constructor($$source: Partial<InvoiceItem> = {}) {
+        if (!("ID" in $$source)) {
+            this["ID"] = 0;
+        }
+        if (!("CreatedAt" in $$source)) {
+            this["CreatedAt"] = null;
+        }
+        if (!("UpdatedAt" in $$source)) {
+            this["UpdatedAt"] = null;
+        }
+        if (!("DeletedAt" in $$source)) {
+            this["DeletedAt"] = null;
+        }
+        if (!("InvoiceID" in $$source)) {
+            this["InvoiceID"] = 0;
+        }
+        if (!("CatalogItemID" in $$source)) {
+            this["CatalogItemID"] = null;
+        }
+        if (!("Code" in $$source)) {
+            this["Code"] = "";
+        }
+        if (!("Description" in $$source)) {
+            this["Description"] = "";
+        }
+        if (!("Quantity" in $$source)) {
+            this["Quantity"] = null;
+        }
+        if (!("Unit" in $$source)) {
+            this["Unit"] = "";
+        }
+        if (!("UnitPrice" in $$source)) {
+            this["UnitPrice"] = null;
+        }
+        if (!("TaxRate" in $$source)) {
+            this["TaxRate"] = null;
+        }
+        if (!("DiscountRate" in $$source)) {
+            this["DiscountRate"] = null;
+        }
+        if (!("DiscountAmount" in $$source)) {
+            this["DiscountAmount"] = null;
+        }
+        if (!("Total" in $$source)) {
+            this["Total"] = null;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new InvoiceItem instance from a string or object.
+     */
+    static createFrom($$source: any = {}): InvoiceItem {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new InvoiceItem($$parsedSource as Partial<InvoiceItem>);
+    }
+}
+
+/**
+ * InvoiceTaxLine is one row of the tax breakdown: the taxable base and tax amount for a single rate.
+ */
+export class InvoiceTaxLine {
+    "ID": number;
+    "CreatedAt": time$0.Time;
+    "UpdatedAt": time$0.Time;
+    "DeletedAt": gorm$0.DeletedAt;
+    "InvoiceID": number;
+
+    /**
+     * percentage
+     */
+    "TaxRate": Decimal;
+
+    /**
+     * item totals taxed at TaxRate, net of their share of the invoice discount
+     */
+    "TaxableBase": Decimal;
+
+    /**
+     * TaxableBase * TaxRate, rounded to the invoice currency
+     */
+    "TaxAmount": Decimal;
+
+    /** Creates a new InvoiceTaxLine instance. */
+    constructor($$source: Partial<InvoiceTaxLine> = {}) {
+        if (!("ID" in $$source)) {
+            this["ID"] = 0;
+        }
+        if (!("CreatedAt" in $$source)) {
+            this["CreatedAt"] = null;
+        }
+        if (!("UpdatedAt" in $$source)) {
+            this["UpdatedAt"] = null;
+        }
+        if (!("DeletedAt" in $$source)) {
+            this["DeletedAt"] = null;
+        }
+        if (!("InvoiceID" in $$source)) {
+            this["InvoiceID"] = 0;
+        }
+        if (!("TaxRate" in $$source)) {
+            this["TaxRate"] = null;
+        }
+        if (!("TaxableBase" in $$source)) {
+            this["TaxableBase"] = null;
+        }
+        if (!("TaxAmount" in $$source)) {
+            this["TaxAmount"] = null;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new InvoiceTaxLine instance from a string or object.
+     */
+    static createFrom($$source: any = {}): InvoiceTaxLine {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new InvoiceTaxLine($$parsedSource as Partial<InvoiceTaxLine>);
+    }
+}
+
+/**
+ * NumberingSeries is a named numbering sequence of a company for one document type.
+ * 
+ * Pattern placeholders:
+ * 
+ * 	{YYYY} fiscal year, e.g. 2025
+ * 	{YY}   two-digit fiscal year, e.g. 25
+ * 	{0000} sequence number, zero-padded to the number of zeros (use {0} for no padding)
+ * 
+ * So "INV-{YYYY}-{0000}" yields "INV-2025-0001".
+ */
+export class NumberingSeries {
+    "ID": number;
+    "CreatedAt": time$0.Time;
+    "UpdatedAt": time$0.Time;
+    "DeletedAt": gorm$0.DeletedAt;
+    "CompanyID": number;
+
+    /**
+     * e.g. "Invoices", "Rectificativas"
+     */
+    "Name": string;
+
+    /**
+     * DocumentTypeInvoice, DocumentTypeCreditNote, ...
+     */
+    "DocumentType": string;
+
+    /**
+     * see type documentation
+     */
+    "Pattern": string;
+
+    /**
+     * ResetNever or ResetYearly
+     */
+    "ResetPolicy": string;
+
+    /**
+     * used when a document is created without an explicit series
+     */
+    "IsDefault": boolean;
+
+    /** Creates a new NumberingSeries instance. */
+    constructor($$source: Partial<NumberingSeries> = {}) {
+        if (!("ID" in $$source)) {
+            this["ID"] = 0;
+        }
+        if (!("CreatedAt" in $$source)) {
+            this["CreatedAt"] = null;
+        }
+        if (!("UpdatedAt" in $$source)) {
+            this["UpdatedAt"] = null;
+        }
+        if (!("DeletedAt" in $$source)) {
+            this["DeletedAt"] = null;
+        }
+        if (!("CompanyID" in $$source)) {
+            this["CompanyID"] = 0;
+        }
+        if (!("Name" in $$source)) {
+            this["Name"] = "";
+        }
+        if (!("DocumentType" in $$source)) {
+            this["DocumentType"] = "";
+        }
+        if (!("Pattern" in $$source)) {
+            this["Pattern"] = "";
+        }
+        if (!("ResetPolicy" in $$source)) {
+            this["ResetPolicy"] = "";
+        }
+        if (!("IsDefault" in $$source)) {
+            this["IsDefault"] = false;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new NumberingSeries instance from a string or object.
+     */
+    static createFrom($$source: any = {}): NumberingSeries {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new NumberingSeries($$parsedSource as Partial<NumberingSeries>);
     }
 }
 
 /**
- * CompanyDefaults stores default configuration for a company (one-to-one).
+ * Payment is money received against an invoice. Voided payments are kept for the record
+ * but no longer count towards the amount paid.
  */
-export class CompanyDefaults {
+export class Payment {
     "ID": number;
     "CreatedAt": time$0.Time;
     "UpdatedAt": time$0.Time;
     "DeletedAt": gorm$0.DeletedAt;
-    "CompanyID": number;
-    "Company": Company;
+    "InvoiceID": number;
 
     /**
-     * ISO 4217 code e.g. "USD", "EUR"
+     * ISO date (YYYY-MM-DD)
      */
-    "DefaultCurrency": string;
+    "Date": string;
 
     /**
-     * percentage, e.g., 21.0
+     * positive amount in the invoice currency
      */
-    "DefaultTaxRate": number;
-    "DefaultFooterText": string;
+    "Amount": Decimal;
 
-    /** Creates a new CompanyDefaults instance. */
-    constructor($$source: Partial<CompanyDefaults> = {}) {
+    /**
+     * e.g. "Bank transfer", "Card", "Cash"
+     */
+    "Method": string;
+
+    /**
+     * bank reference, transaction ID, cheque number...
+     */
+    "Reference": string;
+
+    /**
+     * optional free text
+     */
+    "Notes": string;
+
+    /**
+     * set when the payment was voided
+     */
+    "VoidedAt": time$0.Time | null;
+
+    /** Creates a new Payment instance. */
+    constructor($$source: Partial<Payment> = {}) {
         if (!("ID" in $$source)) {
             this["ID"] = 0;
         }
@@ -208,160 +1774,230 @@ export class CompanyDefaults {
         if (!("DeletedAt" in $$source)) {
             this["DeletedAt"] = null;
         }
-        if (!("CompanyID" in $$source)) {
-            this["CompanyID"] = 0;
+        if (!("InvoiceID" in $$source)) {
+            this["InvoiceID"] = 0;
         }
-        if (!("Company" in $$source)) {
-            this["Company"] = (new Company());
+        if (!("Date" in $$source)) {
+            this["Date"] = "";
         }
-        if (!("DefaultCurrency" in $$source)) {
-            this["DefaultCurrency"] = "";
+        if (!("Amount" in $$source)) {
+            this["Amount"] = null;
         }
-        if (!("DefaultTaxRate" in $$source)) {
-            this["DefaultTaxRate"] = 0;
+        if (!("Method" in $$source)) {
+            this["Method"] = "";
         }
-        if (!("DefaultFooterText" in $$source)) {
-            this["DefaultFooterText"] = "";
+        if (!("Reference" in $$source)) {
+            this["Reference"] = "";
+        }
+        if (!("Notes" in $$source)) {
+            this["Notes"] = "";
+        }
+        if (!("VoidedAt" in $$source)) {
+            this["VoidedAt"] = null;
         }
 
         Object.assign(this, $$source);
     }
 
     /**
-     * Creates a new CompanyDefaults instance from a string or object.
+     * Creates a new Payment instance from a string or object.
      */
-    static createFrom($$source: any = {}): CompanyDefaults {
-        const $$createField5_0 = $$createType5;
+    static createFrom($$source: any = {}): Payment {
         let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
-        if ("Company" in $$parsedSource) {
-            $$parsedSource["Company"] = $$createField5_0($$parsedSource["Company"]);
+        return new Payment($$parsedSource as Partial<Payment>);
+    }
+}
+
+/**
+ * PaymentTerms describe when an invoice is due relative to its issue date. It is embedded into
+ * CompanyDefaults, ClientDefaults and Invoice; the zero value means no terms (inherit, or a due date typed by hand).
+ */
+export class PaymentTerms {
+    /**
+     * one of the PaymentTerms* constants; empty for none
+     */
+    "Type": string;
+
+    /**
+     * days for Net and EndOfMonth
+     */
+    "Days": number;
+
+    /** Creates a new PaymentTerms instance. */
+    constructor($$source: Partial<PaymentTerms> = {}) {
+        if (!("Type" in $$source)) {
+            this["Type"] = "";
         }
-        return new CompanyDefaults($$parsedSource as Partial<CompanyDefaults>);
+        if (!("Days" in $$source)) {
+            this["Days"] = 0;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new PaymentTerms instance from a string or object.
+     */
+    static createFrom($$source: any = {}): PaymentTerms {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new PaymentTerms($$parsedSource as Partial<PaymentTerms>);
     }
 }
 
 /**
- * ContactInfo is embedded into Company and Client tables.
+ * PostalAddress is a structured postal address, embedded into the Company and Client tables
+ * with an "address_" column prefix.
  */
-export class ContactInfo {
-    "Email": string | null;
-    "Phone": string | null;
-    "Website": string | null;
+export class PostalAddress {
+    /**
+     * street and number; holds the whole text of addresses entered before structured fields existed
+     */
+    "Line1": string;
 
-    /** Creates a new ContactInfo instance. */
-    constructor($$source: Partial<ContactInfo> = {}) {
-        if (!("Email" in $$source)) {
-            this["Email"] = null;
+    /**
+     * optional: floor, suite, building...
+     */
+    "Line2": string;
+    "PostalCode": string;
+    "City": string;
+
+    /**
+     * state, province or county
+     */
+    "Region": string;
+
+    /**
+     * ISO 3166-1 alpha-2 code, e.g. "ES"
+     */
+    "Country": string;
+
+    /** Creates a new PostalAddress instance. */
+    constructor($$source: Partial<PostalAddress> = {}) {
+        if (!("Line1" in $$source)) {
+            this["Line1"] = "";
         }
-        if (!("Phone" in $$source)) {
-            this["Phone"] = null;
+        if (!("Line2" in $$source)) {
+            this["Line2"] = "";
         }
-        if (!("Website" in $$source)) {
-            this["Website"] = null;
+        if (!("PostalCode" in $$source)) {
+            this["PostalCode"] = "";
+        }
+        if (!("City" in $$source)) {
+            this["City"] = "";
+        }
+        if (!("Region" in $$source)) {
+            this["Region"] = "";
+        }
+        if (!("Country" in $$source)) {
+            this["Country"] = "";
         }
 
         Object.assign(this, $$source);
     }
 
     /**
-     * Creates a new ContactInfo instance from a string or object.
+     * Creates a new PostalAddress instance from a string or object.
      */
-    static createFrom($$source: any = {}): ContactInfo {
+    static createFrom($$source: any = {}): PostalAddress {
         let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
-        return new ContactInfo($$parsedSource as Partial<ContactInfo>);
+        return new PostalAddress($$parsedSource as Partial<PostalAddress>);
     }
 }
 
-export class Invoice {
+/**
+ * Rate is an exchange rate with ten fractional digits, stored as a scaled integer like Decimal so
+ * that ECB quotes (five decimals) and derived inverse or cross rates are kept without rounding them
+ * to amount precision. In the database it is a TEXT column holding the plain decimal string, and in
+ * JSON it is a plain number.
+ */
+export type Rate = any;
+
+/**
+ * RecurringInvoice is a template from which an invoice is issued every interval (e.g. a monthly retainer).
+ */
+export class RecurringInvoice {
     "ID": number;
     "CreatedAt": time$0.Time;
     "UpdatedAt": time$0.Time;
     "DeletedAt": gorm$0.DeletedAt;
-
-    /**
-     * Ownership / FKs
-     */
     "CompanyID": number;
     "ClientID": number;
-    "Company": Company;
-    "Client": Client;
 
     /**
-     * Identification & dates
-     * human-readable invoice number (numeric)
+     * e.g. "Hosting retainer"
      */
-    "Number": number;
+    "Name": string;
 
     /**
-     * ISO date (YYYY-MM-DD)
+     * paused schedules generate nothing
      */
-    "IssueDate": string;
+    "Active": boolean;
 
     /**
-     * ISO date (YYYY-MM-DD)
+     * Schedule
+     * one of the Interval* constants
      */
-    "DueDate": string;
+    "Interval": string;
 
     /**
-     * Fiscal categorization
-     * e.g., 2025
+     * every N intervals; 0 or 1 means every interval
      */
-    "FiscalYear": number;
+    "IntervalCount": number;
 
     /**
-     * Currency & amounts
-     * ISO 4217 code, e.g. "USD", "EUR"
+     * ISO date of the first invoice; its day of month anchors monthly schedules
      */
-    "Currency": string;
+    "StartDate": string;
 
     /**
-     * sum of item totals before tax and discounts
+     * ISO issue date of the next invoice to generate
      */
-    "Subtotal": number;
+    "NextRunDate": string;
 
     /**
-     * percentage, e.g. 21.0 for 21%
+     * optional ISO date; no invoice is issued after it
      */
-    "TaxRate": number;
+    "EndDate": string;
 
     /**
-     * computed tax amount over the taxable base
+     * issue date of the last generated invoice
      */
-    "TaxAmount": number;
+    "LastRunDate": string;
 
     /**
-     * optional absolute discount applied at invoice level
+     * days from issue date to due date; 0 leaves DueDate empty
      */
-    "DiscountAmount": number;
+    "DueDays": number;
 
     /**
-     * grand total after tax and discounts
+     * Invoice template
+     * numbering series; nil uses the company default
      */
-    "Total": number;
+    "SeriesID": number | null;
 
     /**
-     * Status & presentation
-     * e.g. "Draft", "Sent", "Paid", "Overdue"
+     * status of generated invoices: StatusDraft (default) or StatusSent
      */
     "Status": string;
 
     /**
-     * optional footer/notes to show on the PDF
+     * ISO 4217 code
      */
-    "Notes": string | null;
+    "Currency": string;
 
     /**
-     * Footer text printed at the bottom of the invoice PDF
+     * default percentage for items without their own rate
      */
+    "TaxRate": Decimal;
+    "DiscountRate": Decimal;
+    "DiscountAmount": Decimal;
+    "WithholdingRate": Decimal;
+    "Notes": string | null;
     "FooterText": string;
+    "Items": RecurringInvoiceItem[];
 
-    /**
-     * Lines
-     */
-    "Items": InvoiceItem[];
-
-    /** Creates a new Invoice instance. */
-    constructor($$source: Partial<Invoice> = {}) {
+    /** Creates a new RecurringInvoice instance. */
+    constructor($$source: Partial<RecurringInvoice> = {}) {
         if (!("ID" in $$source)) {
             this["ID"] = 0;
         }
@@ -380,44 +2016,53 @@ export class Invoice {
         if (!("ClientID" in $$source)) {
             this["ClientID"] = 0;
         }
-        if (!("Company" in $$source)) {
-            this["Company"] = (new Company());
+        if (!("Name" in $$source)) {
+            this["Name"] = "";
         }
-        if (!("Client" in $$source)) {
-            this["Client"] = (new Client());
+        if (!("Active" in $$source)) {
+            this["Active"] = false;
         }
-        if (!("Number" in $$source)) {
-            this["Number"] = 0;
+        if (!("Interval" in $$source)) {
+            this["Interval"] = "";
         }
-        if (!("IssueDate" in $$source)) {
-            this["IssueDate"] = "";
+        if (!("IntervalCount" in $$source)) {
+            this["IntervalCount"] = 0;
         }
-        if (!("DueDate" in $$source)) {
-            this["DueDate"] = "";
+        if (!("StartDate" in $$source)) {
+            this["StartDate"] = "";
         }
-        if (!("FiscalYear" in $$source)) {
-            this["FiscalYear"] = 0;
+        if (!("NextRunDate" in $$source)) {
+            this["NextRunDate"] = "";
+        }
+        if (!("EndDate" in $$source)) {
+            this["EndDate"] = "";
+        }
+        if (!("LastRunDate" in $$source)) {
+            this["LastRunDate"] = "";
+        }
+        if (!("DueDays" in $$source)) {
+            this["DueDays"] = 0;
+        }
+        if (!("SeriesID" in $$source)) {
+            this["SeriesID"] = null;
+        }
+        if (!("Status" in $$source)) {
+            this["Status"] = "";
         }
         if (!("Currency" in $$source)) {
             this["Currency"] = "";
         }
-        if (!("Subtotal" in $$source)) {
-            this["Subtotal"] = 0;
-        }
         if (!("TaxRate" in $$source)) {
-            this["TaxRate"] = 0;
+            this["TaxRate"] = null;
         }
-        if (!("TaxAmount" in $$source)) {
-            this["TaxAmount"] = 0;
+        if (!("DiscountRate" in $$source)) {
+            this["DiscountRate"] = null;
         }
         if (!("DiscountAmount" in $$source)) {
-            this["DiscountAmount"] = 0;
-        }
-        if (!("Total" in $$source)) {
-            this["Total"] = 0;
+            this["DiscountAmount"] = null;
         }
-        if (!("Status" in $$source)) {
-            this["Status"] = "";
+        if (!("WithholdingRate" in $$source)) {
+            this["WithholdingRate"] = null;
         }
         if (!("Notes" in $$source)) {
             this["Notes"] = null;
@@ -433,47 +2078,55 @@ export class Invoice {
     }
 
     /**
-     * Creates a new Invoice instance from a string or object.
+     * Creates a new RecurringInvoice instance from a string or object.
      */
-    static createFrom($$source: any = {}): Invoice {
-        const $$createField6_0 = $$createType5;
-        const $$createField7_0 = $$createType3;
-        const $$createField21_0 = $$createType7;
+    static createFrom($$source: any = {}): RecurringInvoice {
+        const $$createField24_0 = $$createType19;
         let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
-        if ("Company" in $$parsedSource) {
-            $$parsedSource["Company"] = $$createField6_0($$parsedSource["Company"]);
-        }
-        if ("Client" in $$parsedSource) {
-            $$parsedSource["Client"] = $$createField7_0($$parsedSource["Client"]);
-        }
         if ("Items" in $$parsedSource) {
-            $$parsedSource["Items"] = $$createField21_0($$parsedSource["Items"]);
+            $$parsedSource["Items"] = $$createField24_0($$parsedSource["Items"]);
         }
-        return new Invoice($$parsedSource as Partial<Invoice>);
+        return new RecurringInvoice($$parsedSource as Partial<RecurringInvoice>);
     }
 }
 
-export class InvoiceItem {
+/**
+ * RecurringInvoiceItem is a line copied onto every invoice generated from a RecurringInvoice.
+ */
+export class RecurringInvoiceItem {
     "ID": number;
     "CreatedAt": time$0.Time;
     "UpdatedAt": time$0.Time;
     "DeletedAt": gorm$0.DeletedAt;
-    "InvoiceID": number;
+    "RecurringInvoiceID": number;
+
+    /**
+     * catalog entry the line was created from
+     */
+    "CatalogItemID": number | null;
+
+    /**
+     * catalog code / SKU
+     */
+    "Code": string;
     "Description": string;
+    "Quantity": Decimal;
 
     /**
-     * supports fractional quantities (e.g., hours)
+     * unit of measure code, see StandardUnits
      */
-    "Quantity": number;
-    "UnitPrice": number;
+    "Unit": string;
+    "UnitPrice": Decimal;
 
     /**
-     * Quantity * UnitPrice
+     * percentage; nil uses the template TaxRate
      */
-    "Total": number;
+    "TaxRate": Decimal | null;
+    "DiscountRate": Decimal;
+    "DiscountAmount": Decimal;
 
-    /** Creates a new InvoiceItem instance. */
-    constructor($$source: Partial<InvoiceItem> = {}) {
+    /** Creates a new RecurringInvoiceItem instance. */
+    constructor($$source: Partial<RecurringInvoiceItem> = {}) {
         if (!("ID" in $$source)) {
             this["ID"] = 0;
         }
@@ -486,40 +2139,160 @@ export class InvoiceItem {
         if (!("DeletedAt" in $$source)) {
             this["DeletedAt"] = null;
         }
-        if (!("InvoiceID" in $$source)) {
-            this["InvoiceID"] = 0;
+        if (!("RecurringInvoiceID" in $$source)) {
+            this["RecurringInvoiceID"] = 0;
+        }
+        if (!("CatalogItemID" in $$source)) {
+            this["CatalogItemID"] = null;
+        }
+        if (!("Code" in $$source)) {
+            this["Code"] = "";
         }
         if (!("Description" in $$source)) {
             this["Description"] = "";
         }
         if (!("Quantity" in $$source)) {
-            this["Quantity"] = 0;
+            this["Quantity"] = null;
+        }
+        if (!("Unit" in $$source)) {
+            this["Unit"] = "";
         }
         if (!("UnitPrice" in $$source)) {
-            this["UnitPrice"] = 0;
+            this["UnitPrice"] = null;
         }
-        if (!("Total" in $$source)) {
-            this["Total"] = 0;
+        if (!("TaxRate" in $$source)) {
+            this["TaxRate"] = null;
+        }
+        if (!("DiscountRate" in $$source)) {
+            this["DiscountRate"] = null;
+        }
+        if (!("DiscountAmount" in $$source)) {
+            this["DiscountAmount"] = null;
         }
 
         Object.assign(this, $$source);
     }
 
     /**
-     * Creates a new InvoiceItem instance from a string or object.
+     * Creates a new RecurringInvoiceItem instance from a string or object.
      */
-    static createFrom($$source: any = {}): InvoiceItem {
+    static createFrom($$source: any = {}): RecurringInvoiceItem {
         let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
-        return new InvoiceItem($$parsedSource as Partial<InvoiceItem>);
+        return new RecurringInvoiceItem($$parsedSource as Partial<RecurringInvoiceItem>);
+    }
+}
+
+/**
+ * TimeEntry is time worked for a client. Billable entries that are not invoiced yet are turned into
+ * invoice lines by DatabaseService.InvoiceTimeEntries.
+ */
+export class TimeEntry {
+    "ID": number;
+    "CreatedAt": time$0.Time;
+    "UpdatedAt": time$0.Time;
+    "DeletedAt": gorm$0.DeletedAt;
+    "CompanyID": number;
+    "ClientID": number;
+
+    /**
+     * ISO date the work was done
+     */
+    "Date": string;
+
+    /**
+     * hours, e.g. 1.5
+     */
+    "Duration": Decimal;
+
+    /**
+     * entries with the same description and rate become one invoice line
+     */
+    "Description": string;
+
+    /**
+     * hourly rate in the invoice currency
+     */
+    "Rate": Decimal;
+    "Billable": boolean;
+    "Invoiced": boolean;
+
+    /**
+     * invoice the entry was billed on
+     */
+    "InvoiceID": number | null;
+
+    /** Creates a new TimeEntry instance. */
+    constructor($$source: Partial<TimeEntry> = {}) {
+        if (!("ID" in $$source)) {
+            this["ID"] = 0;
+        }
+        if (!("CreatedAt" in $$source)) {
+            this["CreatedAt"] = null;
+        }
+        if (!("UpdatedAt" in $$source)) {
+            this["UpdatedAt"] = null;
+        }
+        if (!("DeletedAt" in $$source)) {
+            this["DeletedAt"] = null;
+        }
+        if (!("CompanyID" in $$source)) {
+            this["CompanyID"] = 0;
+        }
+        if (!("ClientID" in $$source)) {
+            this["ClientID"] = 0;
+        }
+        if (!("Date" in $$source)) {
+            this["Date"] = "";
+        }
+        if (!("Duration" in $$source)) {
+            this["Duration"] = null;
+        }
+        if (!("Description" in $$source)) {
+            this["Description"] = "";
+        }
+        if (!("Rate" in $$source)) {
+            this["Rate"] = null;
+        }
+        if (!("Billable" in $$source)) {
+            this["Billable"] = false;
+        }
+        if (!("Invoiced" in $$source)) {
+            this["Invoiced"] = false;
+        }
+        if (!("InvoiceID" in $$source)) {
+            this["InvoiceID"] = null;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new TimeEntry instance from a string or object.
+     */
+    static createFrom($$source: any = {}): TimeEntry {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new TimeEntry($$parsedSource as Partial<TimeEntry>);
     }
 }
 
 // Private type creation functions
-const $$createType0 = ContactInfo.createFrom;
-const $$createType1 = Invoice.createFrom;
-const $$createType2 = $Create.Array($$createType1);
-const $$createType3 = Client.createFrom;
-const $$createType4 = $Create.Array($$createType3);
-const $$createType5 = Company.createFrom;
-const $$createType6 = InvoiceItem.createFrom;
+const $$createType0 = PostalAddress.createFrom;
+const $$createType1 = ContactInfo.createFrom;
+const $$createType2 = ClientContact.createFrom;
+const $$createType3 = $Create.Array($$createType2);
+const $$createType4 = ClientAddress.createFrom;
+const $$createType5 = $Create.Array($$createType4);
+const $$createType6 = Invoice.createFrom;
 const $$createType7 = $Create.Array($$createType6);
+const $$createType8 = PaymentTerms.createFrom;
+const $$createType9 = Client.createFrom;
+const $$createType10 = $Create.Array($$createType9);
+const $$createType11 = Company.createFrom;
+const $$createType12 = InvoiceItem.createFrom;
+const $$createType13 = $Create.Array($$createType12);
+const $$createType14 = InvoiceTaxLine.createFrom;
+const $$createType15 = $Create.Array($$createType14);
+const $$createType16 = Payment.createFrom;
+const $$createType17 = $Create.Array($$createType16);
+const $$createType18 = RecurringInvoiceItem.createFrom;
+const $$createType19 = $Create.Array($$createType18);
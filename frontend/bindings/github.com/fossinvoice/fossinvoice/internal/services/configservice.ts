@@ -5,6 +5,10 @@
 // @ts-ignore: Unused imports
 import { Call as $Call, CancellablePromise as $CancellablePromise, Create as $Create } from "@wailsio/runtime";
 
+// eslint-disable-next-line @typescript-eslint/ban-ts-comment
+// @ts-ignore: Unused imports
+import * as $models from "./models.js";
+
 /**
  * GetLanguage returns the persisted language or empty string if not set.
  */
@@ -12,9 +16,23 @@ export function GetLanguage(): $CancellablePromise<string> {
     return $Call.ByID(1915566495);
 }
 
+/**
+ * ListUnits returns the standard units of measure with names in the given language
+ * (the persisted language when empty).
+ */
+export function ListUnits(lang: string): $CancellablePromise<$models.UnitOption[]> {
+    return $Call.ByID(3481542066, lang).then(($result: any) => {
+        return $$createType1($result);
+    });
+}
+
 /**
  * SetLanguage persists the application language.
  */
 export function SetLanguage(lang: string): $CancellablePromise<boolean> {
     return $Call.ByID(1461740427, lang);
 }
+
+// Private type creation functions
+const $$createType0 = $models.UnitOption.createFrom;
+const $$createType1 = $Create.Array($$createType0);
@@ -19,12 +19,72 @@ import * as models$0 from "../models/models.js";
 // @ts-ignore: Unused imports
 import * as $models from "./models.js";
 
+/**
+ * AddInvoiceAttachment stores the file at filePath with an invoice. Files can be attached to invoices
+ * in any status. Files larger than 20 MB are rejected with ErrFileTooLarge.
+ */
+export function AddInvoiceAttachment(databasePath: string, invoiceID: number, filePath: string): $CancellablePromise<models$0.Attachment | null> {
+    return $Call.ByID(571007775, databasePath, invoiceID, filePath).then(($result: any) => {
+        return $$createType1($result);
+    });
+}
+
+/**
+ * ConvertQuoteToInvoice creates a Draft invoice from a quote, copying its client, currency, rates,
+ * discount, notes and items, and marks the quote Accepted. The invoice is dated today and numbered
+ * like one created with CreateInvoice. Only quotes that were sent (or already accepted) can be converted,
+ * and a quote converts only once.
+ */
+export function ConvertQuoteToInvoice(databasePath: string, quoteID: number): $CancellablePromise<models$0.Invoice | null> {
+    return $Call.ByID(247293635, databasePath, quoteID).then(($result: any) => {
+        return $$createType3($result);
+    });
+}
+
+/**
+ * CreateBankAccount inserts a bank account for a company. IBAN and BIC are validated when given.
+ */
+export function CreateBankAccount(databasePath: string, companyID: number, account: models$0.BankAccount): $CancellablePromise<models$0.BankAccount | null> {
+    return $Call.ByID(1854743917, databasePath, companyID, account).then(($result: any) => {
+        return $$createType5($result);
+    });
+}
+
+/**
+ * CreateCatalogItem inserts a catalog entry for a company.
+ */
+export function CreateCatalogItem(databasePath: string, companyID: number, item: models$0.CatalogItem): $CancellablePromise<models$0.CatalogItem | null> {
+    return $Call.ByID(3104076004, databasePath, companyID, item).then(($result: any) => {
+        return $$createType7($result);
+    });
+}
+
 /**
  * CreateClient inserts a new client linked to the provided company and returns it with the assigned ID.
  */
 export function CreateClient(databasePath: string, companyID: number, client: models$0.Client): $CancellablePromise<models$0.Client | null> {
     return $Call.ByID(3776761531, databasePath, companyID, client).then(($result: any) => {
-        return $$createType1($result);
+        return $$createType9($result);
+    });
+}
+
+/**
+ * CreateClientAddress adds an address to a client. If it is marked as default, any other default
+ * address of the client with the same role loses the flag.
+ */
+export function CreateClientAddress(databasePath: string, clientID: number, address: models$0.ClientAddress): $CancellablePromise<models$0.ClientAddress | null> {
+    return $Call.ByID(3699340505, databasePath, clientID, address).then(($result: any) => {
+        return $$createType11($result);
+    });
+}
+
+/**
+ * CreateClientContact adds a contact to a client. If it is marked as default, any other default
+ * contact of the client with the same role loses the flag.
+ */
+export function CreateClientContact(databasePath: string, clientID: number, contact: models$0.ClientContact): $CancellablePromise<models$0.ClientContact | null> {
+    return $Call.ByID(1955510941, databasePath, clientID, contact).then(($result: any) => {
+        return $$createType13($result);
     });
 }
 
@@ -33,19 +93,109 @@ export function CreateClient(databasePath: string, companyID: number, client: mo
  */
 export function CreateCompany(databasePath: string, company: models$0.Company): $CancellablePromise<models$0.Company | null> {
     return $Call.ByID(3225992131, databasePath, company).then(($result: any) => {
+        return $$createType15($result);
+    });
+}
+
+/**
+ * CreateCreditNote issues a credit note against an existing invoice.
+ * Company, client, currency and rates are copied from the original. If creditNote has no items, every
+ * item of the original is credited (full cancellation); otherwise only the given items are (partial refund).
+ * Quantities are stored negative. Unless Number or SeriesID is given, the credit note is numbered from the
+ * company's default credit note series, or sequentially after the previous credit note if none is configured;
+ * a Draft credit note is only numbered when it is issued.
+ */
+export function CreateCreditNote(databasePath: string, originalInvoiceID: number, creditNote: models$0.Invoice): $CancellablePromise<models$0.Invoice | null> {
+    return $Call.ByID(577533601, databasePath, originalInvoiceID, creditNote).then(($result: any) => {
         return $$createType3($result);
     });
 }
 
+/**
+ * CreateExpense inserts an expense for a company. Total is computed from Net and Tax; the receipt is
+ * attached afterwards with SetExpenseReceipt.
+ */
+export function CreateExpense(databasePath: string, companyID: number, expense: models$0.Expense): $CancellablePromise<models$0.Expense | null> {
+    return $Call.ByID(1908762616, databasePath, companyID, expense).then(($result: any) => {
+        return $$createType17($result);
+    });
+}
+
 /**
  * CreateInvoice inserts a new invoice (and its items) ensuring the client belongs to the company.
+ * Item totals, subtotal, tax and grand total are recomputed from the lines; values sent by the caller are ignored.
+ * Leave Number at 0 to allocate it from SeriesID or the company's default series (see assignNumber);
+ * drafts get their number only when they are issued.
+ * New invoices start as Draft (the default) or Sent. Credit notes are created with CreateCreditNote instead.
  */
 export function CreateInvoice(databasePath: string, invoice: models$0.Invoice): $CancellablePromise<models$0.Invoice | null> {
     return $Call.ByID(103672631, databasePath, invoice).then(($result: any) => {
-        return $$createType5($result);
+        return $$createType3($result);
+    });
+}
+
+/**
+ * CreateNumberingSeries inserts a new numbering series for a company.
+ * If it is marked as default, any other default series of the same document type loses the flag.
+ */
+export function CreateNumberingSeries(databasePath: string, companyID: number, series: models$0.NumberingSeries): $CancellablePromise<models$0.NumberingSeries | null> {
+    return $Call.ByID(1421122490, databasePath, companyID, series).then(($result: any) => {
+        return $$createType19($result);
     });
 }
 
+/**
+ * CreateQuote inserts a new quote (estimate) with its items. Quotes are stored alongside invoices with
+ * DocumentType Quote and numbered on their own: from SeriesID, the company's default quote series, or
+ * sequentially after the previous quote. Totals are computed as for invoices.
+ */
+export function CreateQuote(databasePath: string, quote: models$0.Invoice): $CancellablePromise<models$0.Invoice | null> {
+    return $Call.ByID(3832631192, databasePath, quote).then(($result: any) => {
+        return $$createType3($result);
+    });
+}
+
+/**
+ * CreateRecurringInvoice inserts a recurring invoice schedule (and its items) for a company.
+ * NextRunDate defaults to StartDate, so the first invoice is issued on the start date.
+ */
+export function CreateRecurringInvoice(databasePath: string, companyID: number, rec: models$0.RecurringInvoice): $CancellablePromise<models$0.RecurringInvoice | null> {
+    return $Call.ByID(3604021962, databasePath, companyID, rec).then(($result: any) => {
+        return $$createType21($result);
+    });
+}
+
+/**
+ * CreateTimeEntry inserts a time entry for a client of the company.
+ */
+export function CreateTimeEntry(databasePath: string, companyID: number, entry: models$0.TimeEntry): $CancellablePromise<models$0.TimeEntry | null> {
+    return $Call.ByID(4085491291, databasePath, companyID, entry).then(($result: any) => {
+        return $$createType23($result);
+    });
+}
+
+/**
+ * DeleteAttachment deletes an attachment permanently, along with its content.
+ */
+export function DeleteAttachment(databasePath: string, attachmentID: number): $CancellablePromise<void> {
+    return $Call.ByID(3552044238, databasePath, attachmentID);
+}
+
+/**
+ * DeleteBankAccount deletes a bank account. It stops being the company default and documents that can
+ * still be edited stop using it; issued invoices keep printing it.
+ */
+export function DeleteBankAccount(databasePath: string, accountID: number): $CancellablePromise<void> {
+    return $Call.ByID(4242744456, databasePath, accountID);
+}
+
+/**
+ * DeleteCatalogItem deletes a catalog entry. Invoice lines created from it keep their snapshot.
+ */
+export function DeleteCatalogItem(databasePath: string, catalogItemID: number): $CancellablePromise<void> {
+    return $Call.ByID(1950772525, databasePath, catalogItemID);
+}
+
 /**
  * DeleteClient deletes a client and its related data (invoices and invoice items) in a transaction.
  */
@@ -53,6 +203,29 @@ export function DeleteClient(databasePath: string, clientID: number): $Cancellab
     return $Call.ByID(1547736180, databasePath, clientID);
 }
 
+/**
+ * DeleteClientAddress deletes an address. Documents that can still be edited stop using it;
+ * issued ones keep printing it.
+ */
+export function DeleteClientAddress(databasePath: string, addressID: number): $CancellablePromise<void> {
+    return $Call.ByID(2964146996, databasePath, addressID);
+}
+
+/**
+ * DeleteClientContact deletes a contact. Documents that can still be edited stop using it;
+ * issued ones keep printing it.
+ */
+export function DeleteClientContact(databasePath: string, contactID: number): $CancellablePromise<void> {
+    return $Call.ByID(3625737640, databasePath, contactID);
+}
+
+/**
+ * DeleteClientDefaults removes the defaults of a client so it inherits the company defaults again.
+ */
+export function DeleteClientDefaults(databasePath: string, clientID: number): $CancellablePromise<void> {
+    return $Call.ByID(2263402318, databasePath, clientID);
+}
+
 /**
  * DeleteCompany deletes a company and its related data (clients, invoices, invoice items) in a transaction.
  */
@@ -61,18 +234,114 @@ export function DeleteCompany(databasePath: string, companyID: number): $Cancell
 }
 
 /**
- * DeleteInvoice deletes an invoice and its items in a transaction.
+ * DeleteExchangeRate deletes a stored rate. Invoices keep the rate snapshot they were issued with.
+ */
+export function DeleteExchangeRate(databasePath: string, rateID: number): $CancellablePromise<void> {
+    return $Call.ByID(1138352344, databasePath, rateID);
+}
+
+/**
+ * DeleteExpense deletes an expense permanently, along with its receipt.
+ */
+export function DeleteExpense(databasePath: string, expenseID: number): $CancellablePromise<void> {
+    return $Call.ByID(594834641, databasePath, expenseID);
+}
+
+/**
+ * DeleteInvoice deletes a draft invoice with its items, tax breakdown, payments and attachments in a transaction.
+ * Time entries billed on it become billable again. Documents that have left Draft cannot be deleted
+ * (*models.InvoiceLockedError); they are voided or credited instead.
  */
 export function DeleteInvoice(databasePath: string, invoiceID: number): $CancellablePromise<void> {
     return $Call.ByID(2602720426, databasePath, invoiceID);
 }
 
+/**
+ * DeleteNumberingSeries deletes a numbering series. Documents keep the numbers they were given.
+ */
+export function DeleteNumberingSeries(databasePath: string, seriesID: number): $CancellablePromise<void> {
+    return $Call.ByID(2264357831, databasePath, seriesID);
+}
+
+/**
+ * DeleteRecurringInvoice deletes a schedule and its items. Invoices it generated are kept.
+ */
+export function DeleteRecurringInvoice(databasePath: string, recurringID: number): $CancellablePromise<void> {
+    return $Call.ByID(4072547785, databasePath, recurringID);
+}
+
+/**
+ * DeleteTimeEntry deletes a time entry. Invoiced entries cannot be deleted.
+ */
+export function DeleteTimeEntry(databasePath: string, entryID: number): $CancellablePromise<void> {
+    return $Call.ByID(3921267666, databasePath, entryID);
+}
+
+/**
+ * ExportExpenseReceipt writes the receipt of an expense to outPath, creating parent directories if necessary.
+ */
+export function ExportExpenseReceipt(databasePath: string, expenseID: number, outPath: string): $CancellablePromise<void> {
+    return $Call.ByID(2362867974, databasePath, expenseID, outPath);
+}
+
+/**
+ * ExtractAttachment writes an attachment to outPath, creating parent directories if necessary. The
+ * content is checked against its SHA-256 digest first.
+ */
+export function ExtractAttachment(databasePath: string, attachmentID: number, outPath: string): $CancellablePromise<void> {
+    return $Call.ByID(2152878636, databasePath, attachmentID, outPath);
+}
+
+/**
+ * GenerateRecurringInvoices issues every invoice that fell due up to today for the active schedules
+ * of a company (all companies when companyID is 0), catching up on every missed period. Each invoice
+ * is numbered like one created with CreateInvoice, dated on its scheduled day, and the schedule's
+ * NextRunDate is advanced. It returns the generated invoices.
+ */
+export function GenerateRecurringInvoices(databasePath: string, companyID: number): $CancellablePromise<models$0.Invoice[]> {
+    return $Call.ByID(2387683098, databasePath, companyID).then(($result: any) => {
+        return $$createType24($result);
+    });
+}
+
+/**
+ * GetBaseCurrencyReport returns the totals of a company's issued documents (drafts, void invoices and
+ * quotes excluded) and expenses converted to its default currency. If fiscalYear > 0, only that year
+ * is included. Each document is converted with the rate snapshot it was issued with; documents issued
+ * under another base currency, or before rates were recorded, and expenses are converted at the stored
+ * rate of their date.
+ */
+export function GetBaseCurrencyReport(databasePath: string, companyID: number, fiscalYear: number): $CancellablePromise<$models.BaseCurrencyReport | null> {
+    return $Call.ByID(2064747088, databasePath, companyID, fiscalYear).then(($result: any) => {
+        return $$createType26($result);
+    });
+}
+
+/**
+ * GetCatalogItem returns a single catalog entry.
+ */
+export function GetCatalogItem(databasePath: string, catalogItemID: number): $CancellablePromise<models$0.CatalogItem | null> {
+    return $Call.ByID(262804444, databasePath, catalogItemID).then(($result: any) => {
+        return $$createType7($result);
+    });
+}
+
 /**
  * GetClient returns a single client by ID.
  */
 export function GetClient(databasePath: string, clientID: number): $CancellablePromise<models$0.Client | null> {
     return $Call.ByID(2877816371, databasePath, clientID).then(($result: any) => {
-        return $$createType1($result);
+        return $$createType9($result);
+    });
+}
+
+/**
+ * GetClientDefaults returns the defaults of a client. Clients without their own defaults get an
+ * empty, unsaved record in which every field inherits the company value.
+ */
+export function GetClientDefaults(databasePath: string, clientID: number): $CancellablePromise<models$0.ClientDefaults | null> {
+    return $Call.ByID(1633550069, databasePath, clientID).then(($result: any) => {
+        return $$createType28($result);
     });
 }
 
@@ -81,38 +350,128 @@ export function GetClient(databasePath: string, clientID: number): $CancellableP
  */
 export function GetCompanyDefaults(databasePath: string, companyID: number): $CancellablePromise<models$0.CompanyDefaults | null> {
     return $Call.ByID(3179294701, databasePath, companyID).then(($result: any) => {
-        return $$createType7($result);
+        return $$createType30($result);
     });
 }
 
 /**
- * GetInvoice returns a single invoice with its items preloaded.
+ * GetExpense returns a single expense, without the receipt content.
+ */
+export function GetExpense(databasePath: string, expenseID: number): $CancellablePromise<models$0.Expense | null> {
+    return $Call.ByID(2342934608, databasePath, expenseID).then(($result: any) => {
+        return $$createType17($result);
+    });
+}
+
+/**
+ * GetInvoice returns a single invoice with its items, tax breakdown and payments preloaded.
  */
 export function GetInvoice(databasePath: string, invoiceID: number): $CancellablePromise<models$0.Invoice | null> {
     return $Call.ByID(1834309679, databasePath, invoiceID).then(($result: any) => {
-        return $$createType5($result);
+        return $$createType3($result);
     });
 }
 
 /**
- * GetMaxInvoiceNumber returns the largest numeric invoice number for a company.
- * It considers only invoice numbers that are purely numeric (e.g., "1", "42").
- * If no numeric invoice numbers exist, it returns 0.
+ * GetInvoiceBalance returns the outstanding balance of an invoice: its total reduced by its credit notes and payments.
+ */
+export function GetInvoiceBalance(databasePath: string, invoiceID: number): $CancellablePromise<models$0.Decimal> {
+    return $Call.ByID(472441725, databasePath, invoiceID);
+}
+
+/**
+ * GetInvoiceDefaults returns the values a new invoice starts with: the company defaults with the
+ * client's defaults merged on top. With clientID 0 only the company defaults are used.
+ */
+export function GetInvoiceDefaults(databasePath: string, companyID: number, clientID: number): $CancellablePromise<models$0.InvoiceDefaults | null> {
+    return $Call.ByID(1219186993, databasePath, companyID, clientID).then(($result: any) => {
+        return $$createType32($result);
+    });
+}
+
+/**
+ * GetMaxInvoiceNumber returns the largest manually assigned invoice number for a company.
+ * Deleted invoices are included so a number is never handed out twice; series-allocated
+ * numbers and credit notes have their own sequences and are ignored (see PreviewNextNumber).
+ * If no such invoice numbers exist, it returns 0.
  */
 export function GetMaxInvoiceNumber(databasePath: string, companyID: number): $CancellablePromise<number> {
     return $Call.ByID(568800844, databasePath, companyID);
 }
 
+/**
+ * GetRecurringInvoice returns a single recurring invoice schedule with its items.
+ */
+export function GetRecurringInvoice(databasePath: string, recurringID: number): $CancellablePromise<models$0.RecurringInvoice | null> {
+    return $Call.ByID(2374786642, databasePath, recurringID).then(($result: any) => {
+        return $$createType21($result);
+    });
+}
+
+/**
+ * ImportExchangeRates reads an ECB reference rates file (the eurofxref daily or historical
+ * download, as XML or CSV) and stores its rates with EUR as base currency, replacing existing
+ * rates of the same days. It returns the number of rates imported.
+ */
+export function ImportExchangeRates(databasePath: string, filePath: string): $CancellablePromise<number> {
+    return $Call.ByID(1157874915, databasePath, filePath);
+}
+
+/**
+ * Init opens (creating and migrating if needed) the database, then issues any recurring invoices
+ * that fell due since the database was last opened. A failing schedule is logged and does not
+ * prevent the database from opening.
+ */
 export function Init(databasePath: string): $CancellablePromise<void> {
     return $Call.ByID(2626867082, databasePath);
 }
 
+/**
+ * InvoiceTimeEntries creates a Draft invoice for the unbilled time of a client between from and to
+ * (inclusive ISO dates; empty leaves the range open) and marks the entries as invoiced. Entries with
+ * the same description and rate are grouped into one line in hours, in order of their first date.
+ * Currency, terms and rates come from the company and client defaults. Deleting the draft makes
+ * the entries billable again.
+ */
+export function InvoiceTimeEntries(databasePath: string, companyID: number, clientID: number, $from: string, to: string): $CancellablePromise<models$0.Invoice | null> {
+    return $Call.ByID(4138274938, databasePath, companyID, clientID, $from, to).then(($result: any) => {
+        return $$createType3($result);
+    });
+}
+
+/**
+ * ListBankAccounts returns the bank accounts of a company.
+ */
+export function ListBankAccounts(databasePath: string, companyID: number): $CancellablePromise<models$0.BankAccount[]> {
+    return $Call.ByID(2794086630, databasePath, companyID).then(($result: any) => {
+        return $$createType33($result);
+    });
+}
+
+/**
+ * ListClientAddresses returns the additional addresses of a client, defaults first within each role.
+ */
+export function ListClientAddresses(databasePath: string, clientID: number): $CancellablePromise<models$0.ClientAddress[]> {
+    return $Call.ByID(3548476497, databasePath, clientID).then(($result: any) => {
+        return $$createType34($result);
+    });
+}
+
+/**
+ * ListClientContacts returns the additional contacts of a client, defaults first within each role.
+ */
+export function ListClientContacts(databasePath: string, clientID: number): $CancellablePromise<models$0.ClientContact[]> {
+    return $Call.ByID(2603722366, databasePath, clientID).then(($result: any) => {
+        return $$createType35($result);
+    });
+}
+
 /**
  * ListClientInvoices returns invoices for a company and specific client with optional fiscal year filter.
  */
 export function ListClientInvoices(databasePath: string, companyID: number, clientID: number, fiscalYear: number): $CancellablePromise<models$0.Invoice[]> {
     return $Call.ByID(947145799, databasePath, companyID, clientID, fiscalYear).then(($result: any) => {
-        return $$createType8($result);
+        return $$createType24($result);
     });
 }
 
@@ -121,7 +480,7 @@ export function ListClientInvoices(databasePath: string, companyID: number, clie
  */
 export function ListClients(databasePath: string, companyID: number): $CancellablePromise<models$0.Client[]> {
     return $Call.ByID(550700564, databasePath, companyID).then(($result: any) => {
-        return $$createType9($result);
+        return $$createType36($result);
     });
 }
 
@@ -130,7 +489,7 @@ export function ListClients(databasePath: string, companyID: number): $Cancellab
  */
 export function ListClientsPaged(databasePath: string, companyID: number, limit: number, offset: number): $CancellablePromise<$models.ClientsPage | null> {
     return $Call.ByID(244012497, databasePath, companyID, limit, offset).then(($result: any) => {
-        return $$createType11($result);
+        return $$createType38($result);
     });
 }
 
@@ -139,7 +498,7 @@ export function ListClientsPaged(databasePath: string, companyID: number, limit:
  */
 export function ListCompanies(databasePath: string): $CancellablePromise<models$0.Company[]> {
     return $Call.ByID(1498688831, databasePath).then(($result: any) => {
-        return $$createType12($result);
+        return $$createType39($result);
     });
 }
 
@@ -148,45 +507,254 @@ export function ListCompanies(databasePath: string): $CancellablePromise<models$
  */
 export function ListCompaniesPaged(databasePath: string, limit: number, offset: number): $CancellablePromise<$models.CompaniesPage | null> {
     return $Call.ByID(2289554528, databasePath, limit, offset).then(($result: any) => {
-        return $$createType14($result);
+        return $$createType41($result);
+    });
+}
+
+/**
+ * ListCreditNotes returns the credit notes issued against an invoice.
+ */
+export function ListCreditNotes(databasePath: string, invoiceID: number): $CancellablePromise<models$0.Invoice[]> {
+    return $Call.ByID(1821225962, databasePath, invoiceID).then(($result: any) => {
+        return $$createType24($result);
+    });
+}
+
+/**
+ * ListExchangeRates returns stored exchange rates, newest first, optionally filtered by base currency
+ * and currency (empty matches all), with a total count for pagination.
+ */
+export function ListExchangeRates(databasePath: string, baseCurrency: string, currency: string, limit: number, offset: number): $CancellablePromise<$models.ExchangeRatesPage | null> {
+    return $Call.ByID(1168022916, databasePath, baseCurrency, currency, limit, offset).then(($result: any) => {
+        return $$createType43($result);
+    });
+}
+
+/**
+ * ListExpenseCategories returns the distinct categories used by a company's expenses, sorted by name.
+ */
+export function ListExpenseCategories(databasePath: string, companyID: number): $CancellablePromise<string[]> {
+    return $Call.ByID(3964331832, databasePath, companyID).then(($result: any) => {
+        return $$createType44($result);
+    });
+}
+
+/**
+ * ListExpensesPaged returns the expenses of a company, newest first, with a total count for pagination.
+ * If fiscalYear > 0, filters by FiscalYear. If category is not empty, only that category is returned.
+ */
+export function ListExpensesPaged(databasePath: string, companyID: number, fiscalYear: number, category: string, limit: number, offset: number): $CancellablePromise<$models.ExpensesPage | null> {
+    return $Call.ByID(3083748502, databasePath, companyID, fiscalYear, category, limit, offset).then(($result: any) => {
+        return $$createType46($result);
     });
 }
 
 /**
- * ListFiscalYears returns the distinct list of fiscal years present in invoices for a company (descending).
+ * ListFiscalYears returns the distinct list of fiscal years present in invoices and expenses for a company (descending).
  */
 export function ListFiscalYears(databasePath: string, companyID: number): $CancellablePromise<number[]> {
     return $Call.ByID(3319587284, databasePath, companyID).then(($result: any) => {
-        return $$createType15($result);
+        return $$createType47($result);
     });
 }
 
 /**
- * ListInvoices returns invoices for a company with optional filters.
- * If fiscalYear > 0, filters by FiscalYear. If clientID > 0, filters by ClientID.
+ * ListInvoiceAttachments returns the attachments of an invoice in the order they were added, without their content.
+ */
+export function ListInvoiceAttachments(databasePath: string, invoiceID: number): $CancellablePromise<models$0.Attachment[]> {
+    return $Call.ByID(1304909095, databasePath, invoiceID).then(($result: any) => {
+        return $$createType48($result);
+    });
+}
+
+/**
+ * ListInvoices returns invoices (and credit notes) for a company with optional filters.
+ * If fiscalYear > 0, filters by FiscalYear. If clientID > 0, filters by ClientID. Quotes are listed with ListQuotes.
  */
 export function ListInvoices(databasePath: string, companyID: number, fiscalYear: number, clientID: number): $CancellablePromise<models$0.Invoice[]> {
     return $Call.ByID(3585217392, databasePath, companyID, fiscalYear, clientID).then(($result: any) => {
-        return $$createType8($result);
+        return $$createType24($result);
     });
 }
 
 /**
  * ListInvoicesPaged returns invoices for a company with optional filters and pagination.
  * If fiscalYear > 0, filters by FiscalYear. If clientID > 0, filters by ClientID.
+ * If overdueOnly is set, only open invoices past their due date are returned, most overdue first.
+ */
+export function ListInvoicesPaged(databasePath: string, companyID: number, fiscalYear: number, clientID: number, overdueOnly: boolean, limit: number, offset: number): $CancellablePromise<$models.InvoicesPage | null> {
+    return $Call.ByID(3954630861, databasePath, companyID, fiscalYear, clientID, overdueOnly, limit, offset).then(($result: any) => {
+        return $$createType50($result);
+    });
+}
+
+/**
+ * ListNumberingSeries returns the numbering series of a company.
+ */
+export function ListNumberingSeries(databasePath: string, companyID: number): $CancellablePromise<models$0.NumberingSeries[]> {
+    return $Call.ByID(500206550, databasePath, companyID).then(($result: any) => {
+        return $$createType51($result);
+    });
+}
+
+/**
+ * ListPayments returns the payments of an invoice (voided ones included), oldest first.
+ */
+export function ListPayments(databasePath: string, invoiceID: number): $CancellablePromise<models$0.Payment[]> {
+    return $Call.ByID(3920309447, databasePath, invoiceID).then(($result: any) => {
+        return $$createType53($result);
+    });
+}
+
+/**
+ * ListQuotes returns the quotes of a company with optional filters.
+ * If fiscalYear > 0, filters by FiscalYear. If clientID > 0, filters by ClientID.
+ */
+export function ListQuotes(databasePath: string, companyID: number, fiscalYear: number, clientID: number): $CancellablePromise<models$0.Invoice[]> {
+    return $Call.ByID(3583875749, databasePath, companyID, fiscalYear, clientID).then(($result: any) => {
+        return $$createType24($result);
+    });
+}
+
+/**
+ * ListRecurringInvoices returns the recurring invoice schedules of a company with their items.
+ */
+export function ListRecurringInvoices(databasePath: string, companyID: number): $CancellablePromise<models$0.RecurringInvoice[]> {
+    return $Call.ByID(853836679, databasePath, companyID).then(($result: any) => {
+        return $$createType54($result);
+    });
+}
+
+/**
+ * ListTimeEntriesPaged returns the time entries of a company, newest first, with a total count for
+ * pagination. If clientID > 0, filters by ClientID. from and to are inclusive ISO dates; empty leaves
+ * the range open. If unbilledOnly is set, only billable entries not invoiced yet are returned.
+ */
+export function ListTimeEntriesPaged(databasePath: string, companyID: number, clientID: number, $from: string, to: string, unbilledOnly: boolean, limit: number, offset: number): $CancellablePromise<$models.TimeEntriesPage | null> {
+    return $Call.ByID(475300176, databasePath, companyID, clientID, $from, to, unbilledOnly, limit, offset).then(($result: any) => {
+        return $$createType56($result);
+    });
+}
+
+/**
+ * NewInvoiceItemFromCatalog returns an unsaved invoice line for quantity units of a catalog entry,
+ * holding a snapshot of its code, description, price and tax rate.
+ */
+export function NewInvoiceItemFromCatalog(databasePath: string, catalogItemID: number, quantity: models$0.Decimal): $CancellablePromise<models$0.InvoiceItem | null> {
+    return $Call.ByID(3614121945, databasePath, catalogItemID, quantity).then(($result: any) => {
+        return $$createType58($result);
+    });
+}
+
+/**
+ * PreviewNextNumber returns the number the series would hand out next for a fiscal year, without reserving it.
+ */
+export function PreviewNextNumber(databasePath: string, seriesID: number, fiscalYear: number): $CancellablePromise<string> {
+    return $Call.ByID(1517107888, databasePath, seriesID, fiscalYear);
+}
+
+/**
+ * RecordPayment adds a payment to an invoice and updates its amount paid and status
+ * (PartiallyPaid, or Paid once the outstanding balance reaches zero).
+ */
+export function RecordPayment(databasePath: string, invoiceID: number, payment: models$0.Payment): $CancellablePromise<models$0.Payment | null> {
+    return $Call.ByID(712820537, databasePath, invoiceID, payment).then(($result: any) => {
+        return $$createType59($result);
+    });
+}
+
+/**
+ * RemoveExpenseReceipt deletes the receipt of an expense.
+ */
+export function RemoveExpenseReceipt(databasePath: string, expenseID: number): $CancellablePromise<void> {
+    return $Call.ByID(1269064216, databasePath, expenseID);
+}
+
+/**
+ * SearchCatalogItemsPaged returns the catalog entries of a company whose code, name or description
+ * contains query (case-insensitive; empty matches all), ordered by name, with a total count for pagination.
+ * Inactive entries are included only when includeInactive is set.
  */
-export function ListInvoicesPaged(databasePath: string, companyID: number, fiscalYear: number, clientID: number, limit: number, offset: number): $CancellablePromise<$models.InvoicesPage | null> {
-    return $Call.ByID(3954630861, databasePath, companyID, fiscalYear, clientID, limit, offset).then(($result: any) => {
+export function SearchCatalogItemsPaged(databasePath: string, companyID: number, query: string, includeInactive: boolean, limit: number, offset: number): $CancellablePromise<$models.CatalogItemsPage | null> {
+    return $Call.ByID(3135772286, databasePath, companyID, query, includeInactive, limit, offset).then(($result: any) => {
+        return $$createType61($result);
+    });
+}
+
+/**
+ * SetExchangeRate stores a manually entered rate, replacing any rate of the same day and currency pair.
+ */
+export function SetExchangeRate(databasePath: string, rate: models$0.ExchangeRate): $CancellablePromise<models$0.ExchangeRate | null> {
+    return $Call.ByID(3971950855, databasePath, rate).then(($result: any) => {
+        return $$createType63($result);
+    });
+}
+
+/**
+ * SetExpenseReceipt stores the file at filePath as the receipt of an expense, replacing any previous one.
+ * Files larger than 20 MB are rejected with ErrFileTooLarge.
+ */
+export function SetExpenseReceipt(databasePath: string, expenseID: number, filePath: string): $CancellablePromise<models$0.Expense | null> {
+    return $Call.ByID(499309856, databasePath, expenseID, filePath).then(($result: any) => {
         return $$createType17($result);
     });
 }
 
+/**
+ * UpdateBankAccount updates a bank account (ID must be set). Invoices printed afterwards show the new
+ * details, issued ones included.
+ */
+export function UpdateBankAccount(databasePath: string, account: models$0.BankAccount): $CancellablePromise<models$0.BankAccount | null> {
+    return $Call.ByID(1963393110, databasePath, account).then(($result: any) => {
+        return $$createType5($result);
+    });
+}
+
+/**
+ * UpdateCatalogItem updates a catalog entry by primary key (ID must be set). Invoice lines created
+ * from it keep the values they were created with.
+ */
+export function UpdateCatalogItem(databasePath: string, item: models$0.CatalogItem): $CancellablePromise<models$0.CatalogItem | null> {
+    return $Call.ByID(1788722667, databasePath, item).then(($result: any) => {
+        return $$createType7($result);
+    });
+}
+
 /**
  * UpdateClient updates client data by primary key (ID must be set). Returns the updated record.
  */
 export function UpdateClient(databasePath: string, client: models$0.Client): $CancellablePromise<models$0.Client | null> {
     return $Call.ByID(2262375314, databasePath, client).then(($result: any) => {
-        return $$createType1($result);
+        return $$createType9($result);
+    });
+}
+
+/**
+ * UpdateClientAddress updates role, label, address and default flag of an address (ID must be set).
+ * Documents using the address print the updated values, so when a client moves add a new address
+ * and make it the default instead.
+ */
+export function UpdateClientAddress(databasePath: string, address: models$0.ClientAddress): $CancellablePromise<models$0.ClientAddress | null> {
+    return $Call.ByID(407956186, databasePath, address).then(($result: any) => {
+        return $$createType11($result);
+    });
+}
+
+/**
+ * UpdateClientContact updates role, name, contact details and default flag of a contact (ID must be set).
+ */
+export function UpdateClientContact(databasePath: string, contact: models$0.ClientContact): $CancellablePromise<models$0.ClientContact | null> {
+    return $Call.ByID(2024675418, databasePath, contact).then(($result: any) => {
+        return $$createType13($result);
+    });
+}
+
+/**
+ * UpdateClientDefaults upserts the defaults of a client (ClientID must be set).
+ */
+export function UpdateClientDefaults(databasePath: string, def: models$0.ClientDefaults): $CancellablePromise<models$0.ClientDefaults | null> {
+    return $Call.ByID(2984471204, databasePath, def).then(($result: any) => {
+        return $$createType28($result);
     });
 }
 
@@ -195,7 +763,7 @@ export function UpdateClient(databasePath: string, client: models$0.Client): $Ca
  */
 export function UpdateCompany(databasePath: string, company: models$0.Company): $CancellablePromise<models$0.Company | null> {
     return $Call.ByID(483936288, databasePath, company).then(($result: any) => {
-        return $$createType3($result);
+        return $$createType15($result);
     });
 }
 
@@ -204,35 +772,137 @@ export function UpdateCompany(databasePath: string, company: models$0.Company):
  */
 export function UpdateCompanyDefaults(databasePath: string, def: models$0.CompanyDefaults): $CancellablePromise<models$0.CompanyDefaults | null> {
     return $Call.ByID(1989614922, databasePath, def).then(($result: any) => {
-        return $$createType7($result);
+        return $$createType30($result);
+    });
+}
+
+/**
+ * UpdateExpense updates an expense (ID must be set). The receipt is kept.
+ */
+export function UpdateExpense(databasePath: string, expense: models$0.Expense): $CancellablePromise<models$0.Expense | null> {
+    return $Call.ByID(3155124579, databasePath, expense).then(($result: any) => {
+        return $$createType17($result);
     });
 }
 
 /**
  * UpdateInvoice updates invoice header fields and replaces items with provided ones (idempotent) in a transaction.
+ * Like CreateInvoice, all calculated amounts of a draft are recomputed from the lines before saving.
+ * 
+ * Status changes must follow the allowed transitions (Draft → Sent → Paid/Void), otherwise a
+ * *models.StatusTransitionError is returned. Once an invoice has left Draft only its status, due date,
+ * notes, footer, billing contact and bank account can change; everything else is kept as stored, and
+ * giving a different value returns a *models.InvoiceLockedError (see models.Invoice.CheckUpdate).
+ * Quotes are updated here too; they follow Draft → Sent → Accepted/Rejected and lock once decided.
+ * 
+ * When the issue date or payment terms change, the due date is recomputed from the terms. Leaving
+ * PaymentTerms zero keeps the stored terms, and leaving Language empty the stored language.
  */
 export function UpdateInvoice(databasePath: string, invoice: models$0.Invoice): $CancellablePromise<models$0.Invoice | null> {
     return $Call.ByID(4262715900, databasePath, invoice).then(($result: any) => {
-        return $$createType5($result);
+        return $$createType3($result);
     });
 }
 
+/**
+ * UpdateNumberingSeries updates name, pattern, reset policy and default flag of a series (ID must be set).
+ * Numbers already allocated are not affected.
+ */
+export function UpdateNumberingSeries(databasePath: string, series: models$0.NumberingSeries): $CancellablePromise<models$0.NumberingSeries | null> {
+    return $Call.ByID(3189949361, databasePath, series).then(($result: any) => {
+        return $$createType19($result);
+    });
+}
+
+/**
+ * UpdateRecurringInvoice updates a schedule (ID must be set) and replaces its items.
+ * Invoices already generated are not affected; LastRunDate is kept, and so is NextRunDate unless
+ * a new one is given, so editing a schedule does not issue its past invoices again.
+ */
+export function UpdateRecurringInvoice(databasePath: string, rec: models$0.RecurringInvoice): $CancellablePromise<models$0.RecurringInvoice | null> {
+    return $Call.ByID(533538031, databasePath, rec).then(($result: any) => {
+        return $$createType21($result);
+    });
+}
+
+/**
+ * UpdateTimeEntry updates a time entry (ID must be set). Invoiced entries cannot be changed.
+ */
+export function UpdateTimeEntry(databasePath: string, entry: models$0.TimeEntry): $CancellablePromise<models$0.TimeEntry | null> {
+    return $Call.ByID(3272648900, databasePath, entry).then(($result: any) => {
+        return $$createType23($result);
+    });
+}
+
+/**
+ * VoidPayment marks a payment as voided and updates the invoice amount paid and status accordingly.
+ */
+export function VoidPayment(databasePath: string, paymentID: number): $CancellablePromise<void> {
+    return $Call.ByID(2632878840, databasePath, paymentID);
+}
+
 // Private type creation functions
-const $$createType0 = models$0.Client.createFrom;
+const $$createType0 = models$0.Attachment.createFrom;
 const $$createType1 = $Create.Nullable($$createType0);
-const $$createType2 = models$0.Company.createFrom;
+const $$createType2 = models$0.Invoice.createFrom;
 const $$createType3 = $Create.Nullable($$createType2);
-const $$createType4 = models$0.Invoice.createFrom;
+const $$createType4 = models$0.BankAccount.createFrom;
 const $$createType5 = $Create.Nullable($$createType4);
-const $$createType6 = models$0.CompanyDefaults.createFrom;
+const $$createType6 = models$0.CatalogItem.createFrom;
 const $$createType7 = $Create.Nullable($$createType6);
-const $$createType8 = $Create.Array($$createType4);
-const $$createType9 = $Create.Array($$createType0);
-const $$createType10 = $models.ClientsPage.createFrom;
+const $$createType8 = models$0.Client.createFrom;
+const $$createType9 = $Create.Nullable($$createType8);
+const $$createType10 = models$0.ClientAddress.createFrom;
 const $$createType11 = $Create.Nullable($$createType10);
-const $$createType12 = $Create.Array($$createType2);
-const $$createType13 = $models.CompaniesPage.createFrom;
-const $$createType14 = $Create.Nullable($$createType13);
-const $$createType15 = $Create.Array($Create.Any);
-const $$createType16 = $models.InvoicesPage.createFrom;
+const $$createType12 = models$0.ClientContact.createFrom;
+const $$createType13 = $Create.Nullable($$createType12);
+const $$createType14 = models$0.Company.createFrom;
+const $$createType15 = $Create.Nullable($$createType14);
+const $$createType16 = models$0.Expense.createFrom;
 const $$createType17 = $Create.Nullable($$createType16);
+const $$createType18 = models$0.NumberingSeries.createFrom;
+const $$createType19 = $Create.Nullable($$createType18);
+const $$createType20 = models$0.RecurringInvoice.createFrom;
+const $$createType21 = $Create.Nullable($$createType20);
+const $$createType22 = models$0.TimeEntry.createFrom;
+const $$createType23 = $Create.Nullable($$createType22);
+const $$createType24 = $Create.Array($$createType2);
+const $$createType25 = $models.BaseCurrencyReport.createFrom;
+const $$createType26 = $Create.Nullable($$createType25);
+const $$createType27 = models$0.ClientDefaults.createFrom;
+const $$createType28 = $Create.Nullable($$createType27);
+const $$createType29 = models$0.CompanyDefaults.createFrom;
+const $$createType30 = $Create.Nullable($$createType29);
+const $$createType31 = models$0.InvoiceDefaults.createFrom;
+const $$createType32 = $Create.Nullable($$createType31);
+const $$createType33 = $Create.Array($$createType4);
+const $$createType34 = $Create.Array($$createType10);
+const $$createType35 = $Create.Array($$createType12);
+const $$createType36 = $Create.Array($$createType8);
+const $$createType37 = $models.ClientsPage.createFrom;
+const $$createType38 = $Create.Nullable($$createType37);
+const $$createType39 = $Create.Array($$createType14);
+const $$createType40 = $models.CompaniesPage.createFrom;
+const $$createType41 = $Create.Nullable($$createType40);
+const $$createType42 = $models.ExchangeRatesPage.createFrom;
+const $$createType43 = $Create.Nullable($$createType42);
+const $$createType44 = $Create.Array($Create.Any);
+const $$createType45 = $models.ExpensesPage.createFrom;
+const $$createType46 = $Create.Nullable($$createType45);
+const $$createType47 = $Create.Array($Create.Any);
+const $$createType48 = $Create.Array($$createType0);
+const $$createType49 = $models.InvoicesPage.createFrom;
+const $$createType50 = $Create.Nullable($$createType49);
+const $$createType51 = $Create.Array($$createType18);
+const $$createType52 = models$0.Payment.createFrom;
+const $$createType53 = $Create.Array($$createType52);
+const $$createType54 = $Create.Array($$createType20);
+const $$createType55 = $models.TimeEntriesPage.createFrom;
+const $$createType56 = $Create.Nullable($$createType55);
+const $$createType57 = models$0.InvoiceItem.createFrom;
+const $$createType58 = $Create.Nullable($$createType57);
+const $$createType59 = $Create.Nullable($$createType52);
+const $$createType60 = $models.CatalogItemsPage.createFrom;
+const $$createType61 = $Create.Nullable($$createType60);
+const $$createType62 = models$0.ExchangeRate.createFrom;
+const $$createType63 = $Create.Nullable($$createType62);
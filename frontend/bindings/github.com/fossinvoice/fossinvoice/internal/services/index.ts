@@ -13,8 +13,15 @@ export {
 };
 
 export {
+    BaseCurrencyReport,
+    CatalogItemsPage,
     ClientsPage,
     CompaniesPage,
+    CurrencyTotal,
     DialogResponse,
-    InvoicesPage
+    ExchangeRatesPage,
+    ExpensesPage,
+    InvoicesPage,
+    TimeEntriesPage,
+    UnitOption
 } from "./models.js";
@@ -9,6 +9,155 @@ import { Create as $Create } from "@wailsio/runtime";
 // @ts-ignore: Unused imports
 import * as models$0 from "../models/models.js";
 
+/**
+ * BaseCurrencyReport sums a company's issued invoices and credit notes in its base currency, and
+ * its expenses to report profit and the tax balance.
+ */
+export class BaseCurrencyReport {
+    "baseCurrency": string;
+    "fiscalYear": number;
+    "count": number;
+
+    /**
+     * net of invoice discounts
+     */
+    "subtotal": models$0.Decimal;
+    "taxAmount": models$0.Decimal;
+    "withholdingAmount": models$0.Decimal;
+    "total": models$0.Decimal;
+    "amountPaid": models$0.Decimal;
+
+    /**
+     * documents left out because no rate was known
+     */
+    "missingRates": number;
+    "byCurrency": CurrencyTotal[];
+
+    /**
+     * Expenses of the same period, converted at the rate of their date
+     */
+    "expenseCount": number;
+    "expenseNet": models$0.Decimal;
+    "expenseTax": models$0.Decimal;
+    "expenseTotal": models$0.Decimal;
+
+    /**
+     * expenses left out because no rate was known
+     */
+    "expenseMissingRates": number;
+
+    /**
+     * Subtotal - ExpenseNet
+     */
+    "profit": models$0.Decimal;
+
+    /**
+     * TaxAmount - ExpenseTax: tax collected minus tax paid
+     */
+    "taxBalance": models$0.Decimal;
+
+    /** Creates a new BaseCurrencyReport instance. */
+    constructor($$source: Partial<BaseCurrencyReport> = {}) {
+        if (!("baseCurrency" in $$source)) {
+            this["baseCurrency"] = "";
+        }
+        if (!("fiscalYear" in $$source)) {
+            this["fiscalYear"] = 0;
+        }
+        if (!("count" in $$source)) {
+            this["count"] = 0;
+        }
+        if (!("subtotal" in $$source)) {
+            this["subtotal"] = null;
+        }
+        if (!("taxAmount" in $$source)) {
+            this["taxAmount"] = null;
+        }
+        if (!("withholdingAmount" in $$source)) {
+            this["withholdingAmount"] = null;
+        }
+        if (!("total" in $$source)) {
+            this["total"] = null;
+        }
+        if (!("amountPaid" in $$source)) {
+            this["amountPaid"] = null;
+        }
+        if (!("missingRates" in $$source)) {
+            this["missingRates"] = 0;
+        }
+        if (!("byCurrency" in $$source)) {
+            this["byCurrency"] = [];
+        }
+        if (!("expenseCount" in $$source)) {
+            this["expenseCount"] = 0;
+        }
+        if (!("expenseNet" in $$source)) {
+            this["expenseNet"] = null;
+        }
+        if (!("expenseTax" in $$source)) {
+            this["expenseTax"] = null;
+        }
+        if (!("expenseTotal" in $$source)) {
+            this["expenseTotal"] = null;
+        }
+        if (!("expenseMissingRates" in $$source)) {
+            this["expenseMissingRates"] = 0;
+        }
+        if (!("profit" in $$source)) {
+            this["profit"] = null;
+        }
+        if (!("taxBalance" in $$source)) {
+            this["taxBalance"] = null;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new BaseCurrencyReport instance from a string or object.
+     */
+    static createFrom($$source: any = {}): BaseCurrencyReport {
+        const $$createField9_0 = $$createType1;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("byCurrency" in $$parsedSource) {
+            $$parsedSource["byCurrency"] = $$createField9_0($$parsedSource["byCurrency"]);
+        }
+        return new BaseCurrencyReport($$parsedSource as Partial<BaseCurrencyReport>);
+    }
+}
+
+/**
+ * CatalogItemsPage represents a paginated result of catalog entries.
+ */
+export class CatalogItemsPage {
+    "items": models$0.CatalogItem[];
+    "total": number;
+
+    /** Creates a new CatalogItemsPage instance. */
+    constructor($$source: Partial<CatalogItemsPage> = {}) {
+        if (!("items" in $$source)) {
+            this["items"] = [];
+        }
+        if (!("total" in $$source)) {
+            this["total"] = 0;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new CatalogItemsPage instance from a string or object.
+     */
+    static createFrom($$source: any = {}): CatalogItemsPage {
+        const $$createField0_0 = $$createType3;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("items" in $$parsedSource) {
+            $$parsedSource["items"] = $$createField0_0($$parsedSource["items"]);
+        }
+        return new CatalogItemsPage($$parsedSource as Partial<CatalogItemsPage>);
+    }
+}
+
 /**
  * ClientsPage represents a paginated result of clients.
  */
@@ -32,7 +181,7 @@ export class ClientsPage {
      * Creates a new ClientsPage instance from a string or object.
      */
     static createFrom($$source: any = {}): ClientsPage {
-        const $$createField0_0 = $$createType1;
+        const $$createField0_0 = $$createType5;
         let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
         if ("items" in $$parsedSource) {
             $$parsedSource["items"] = $$createField0_0($$parsedSource["items"]);
@@ -64,7 +213,7 @@ export class CompaniesPage {
      * Creates a new CompaniesPage instance from a string or object.
      */
     static createFrom($$source: any = {}): CompaniesPage {
-        const $$createField0_0 = $$createType3;
+        const $$createField0_0 = $$createType7;
         let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
         if ("items" in $$parsedSource) {
             $$parsedSource["items"] = $$createField0_0($$parsedSource["items"]);
@@ -73,6 +222,58 @@ export class CompaniesPage {
     }
 }
 
+/**
+ * CurrencyTotal is the part of a base-currency report issued in one currency.
+ */
+export class CurrencyTotal {
+    "currency": string;
+    "count": number;
+
+    /**
+     * in Currency
+     */
+    "total": models$0.Decimal;
+
+    /**
+     * converted, invoices without a rate excluded
+     */
+    "baseTotal": models$0.Decimal;
+
+    /**
+     * invoices that could not be converted
+     */
+    "missingRates": number;
+
+    /** Creates a new CurrencyTotal instance. */
+    constructor($$source: Partial<CurrencyTotal> = {}) {
+        if (!("currency" in $$source)) {
+            this["currency"] = "";
+        }
+        if (!("count" in $$source)) {
+            this["count"] = 0;
+        }
+        if (!("total" in $$source)) {
+            this["total"] = null;
+        }
+        if (!("baseTotal" in $$source)) {
+            this["baseTotal"] = null;
+        }
+        if (!("missingRates" in $$source)) {
+            this["missingRates"] = 0;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new CurrencyTotal instance from a string or object.
+     */
+    static createFrom($$source: any = {}): CurrencyTotal {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new CurrencyTotal($$parsedSource as Partial<CurrencyTotal>);
+    }
+}
+
 export class DialogResponse {
     "Path": string;
     "Error": any;
@@ -98,6 +299,70 @@ export class DialogResponse {
     }
 }
 
+/**
+ * ExchangeRatesPage represents a paginated result of exchange rates.
+ */
+export class ExchangeRatesPage {
+    "items": models$0.ExchangeRate[];
+    "total": number;
+
+    /** Creates a new ExchangeRatesPage instance. */
+    constructor($$source: Partial<ExchangeRatesPage> = {}) {
+        if (!("items" in $$source)) {
+            this["items"] = [];
+        }
+        if (!("total" in $$source)) {
+            this["total"] = 0;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ExchangeRatesPage instance from a string or object.
+     */
+    static createFrom($$source: any = {}): ExchangeRatesPage {
+        const $$createField0_0 = $$createType9;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("items" in $$parsedSource) {
+            $$parsedSource["items"] = $$createField0_0($$parsedSource["items"]);
+        }
+        return new ExchangeRatesPage($$parsedSource as Partial<ExchangeRatesPage>);
+    }
+}
+
+/**
+ * ExpensesPage represents a paginated result of expenses.
+ */
+export class ExpensesPage {
+    "items": models$0.Expense[];
+    "total": number;
+
+    /** Creates a new ExpensesPage instance. */
+    constructor($$source: Partial<ExpensesPage> = {}) {
+        if (!("items" in $$source)) {
+            this["items"] = [];
+        }
+        if (!("total" in $$source)) {
+            this["total"] = 0;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ExpensesPage instance from a string or object.
+     */
+    static createFrom($$source: any = {}): ExpensesPage {
+        const $$createField0_0 = $$createType11;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("items" in $$parsedSource) {
+            $$parsedSource["items"] = $$createField0_0($$parsedSource["items"]);
+        }
+        return new ExpensesPage($$parsedSource as Partial<ExpensesPage>);
+    }
+}
+
 /**
  * InvoicesPage represents a paginated result of invoices.
  */
@@ -121,7 +386,7 @@ export class InvoicesPage {
      * Creates a new InvoicesPage instance from a string or object.
      */
     static createFrom($$source: any = {}): InvoicesPage {
-        const $$createField0_0 = $$createType5;
+        const $$createField0_0 = $$createType13;
         let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
         if ("items" in $$parsedSource) {
             $$parsedSource["items"] = $$createField0_0($$parsedSource["items"]);
@@ -130,10 +395,80 @@ export class InvoicesPage {
     }
 }
 
+/**
+ * TimeEntriesPage represents a paginated result of time entries.
+ */
+export class TimeEntriesPage {
+    "items": models$0.TimeEntry[];
+    "total": number;
+
+    /** Creates a new TimeEntriesPage instance. */
+    constructor($$source: Partial<TimeEntriesPage> = {}) {
+        if (!("items" in $$source)) {
+            this["items"] = [];
+        }
+        if (!("total" in $$source)) {
+            this["total"] = 0;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new TimeEntriesPage instance from a string or object.
+     */
+    static createFrom($$source: any = {}): TimeEntriesPage {
+        const $$createField0_0 = $$createType15;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("items" in $$parsedSource) {
+            $$parsedSource["items"] = $$createField0_0($$parsedSource["items"]);
+        }
+        return new TimeEntriesPage($$parsedSource as Partial<TimeEntriesPage>);
+    }
+}
+
+/**
+ * UnitOption is a unit of measure offered to the user: its UN/ECE Rec 20 code and localized name.
+ */
+export class UnitOption {
+    "code": string;
+    "name": string;
+
+    /** Creates a new UnitOption instance. */
+    constructor($$source: Partial<UnitOption> = {}) {
+        if (!("code" in $$source)) {
+            this["code"] = "";
+        }
+        if (!("name" in $$source)) {
+            this["name"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new UnitOption instance from a string or object.
+     */
+    static createFrom($$source: any = {}): UnitOption {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new UnitOption($$parsedSource as Partial<UnitOption>);
+    }
+}
+
 // Private type creation functions
-const $$createType0 = models$0.Client.createFrom;
+const $$createType0 = CurrencyTotal.createFrom;
 const $$createType1 = $Create.Array($$createType0);
-const $$createType2 = models$0.Company.createFrom;
+const $$createType2 = models$0.CatalogItem.createFrom;
 const $$createType3 = $Create.Array($$createType2);
-const $$createType4 = models$0.Invoice.createFrom;
+const $$createType4 = models$0.Client.createFrom;
 const $$createType5 = $Create.Array($$createType4);
+const $$createType6 = models$0.Company.createFrom;
+const $$createType7 = $Create.Array($$createType6);
+const $$createType8 = models$0.ExchangeRate.createFrom;
+const $$createType9 = $Create.Array($$createType8);
+const $$createType10 = models$0.Expense.createFrom;
+const $$createType11 = $Create.Array($$createType10);
+const $$createType12 = models$0.Invoice.createFrom;
+const $$createType13 = $Create.Array($$createType12);
+const $$createType14 = models$0.TimeEntry.createFrom;
+const $$createType15 = $Create.Array($$createType14);
@@ -9,8 +9,11 @@ import { Call as $Call, CancellablePromise as $CancellablePromise, Create as $Cr
  * ExportInvoicePDF generates a PDF for the given invoice and writes it to outPath.
  * It will create parent directories if necessary and ensure the file has a .pdf extension.
  * ExportInvoicePDF generates a PDF for the given invoice and writes it to outPath.
- * lang is a BCP47 language tag (e.g., "en", "es-ES"). If empty, defaults to English.
+ * Credit notes and quotes are rendered with the same layout under their own title.
+ * lang is a BCP47 language tag (e.g., "en", "es-ES"). Documents with their own Language (see
+ * models.ClientDefaults) are always printed in it. If both are empty, the application language is used.
+ * With embedAttachments, the files attached to the invoice are embedded in the PDF as file attachments.
  */
-export function ExportInvoicePDF(databasePath: string, invoiceID: number, outPath: string, lang: string): $CancellablePromise<void> {
-    return $Call.ByID(1145491626, databasePath, invoiceID, outPath, lang);
+export function ExportInvoicePDF(databasePath: string, invoiceID: number, outPath: string, lang: string, embedAttachments: boolean): $CancellablePromise<void> {
+    return $Call.ByID(1145491626, databasePath, invoiceID, outPath, lang, embedAttachments);
 }
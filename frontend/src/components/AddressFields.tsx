@@ -0,0 +1,28 @@
+import { ChangeEvent } from 'react'
+import { PostalAddress } from '../types/address'
+import { useI18n } from '../i18n'
+
+type Props = {
+  value: PostalAddress
+  onChange: (value: PostalAddress) => void
+}
+
+export default function AddressFields({ value, onChange }: Props) {
+  const { t } = useI18n()
+  const set = (field: keyof PostalAddress) => (e: ChangeEvent<HTMLInputElement>) => onChange({ ...value, [field]: e.target.value })
+
+  return (
+    <div className="grid gap-3">
+      <input className="input" placeholder={t('messages.addressLine1')} value={value.Line1} onChange={set('Line1')} />
+      <input className="input" placeholder={t('messages.addressLine2')} value={value.Line2} onChange={set('Line2')} />
+      <div className="grid sm:grid-cols-3 gap-3">
+        <input className="input" placeholder={t('messages.postalCode')} value={value.PostalCode} onChange={set('PostalCode')} />
+        <input className="input sm:col-span-2" placeholder={t('messages.city')} value={value.City} onChange={set('City')} />
+      </div>
+      <div className="grid sm:grid-cols-3 gap-3">
+        <input className="input sm:col-span-2" placeholder={t('messages.region')} value={value.Region} onChange={set('Region')} />
+        <input className="input" placeholder={t('messages.country')} maxLength={2} value={value.Country} onChange={set('Country')} />
+      </div>
+    </div>
+  )
+}
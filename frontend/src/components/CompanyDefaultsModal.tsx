@@ -2,11 +2,15 @@ import { useEffect, useMemo, useState } from 'react'
 import Modal from './Modal'
 import { COMMON_CURRENCIES, withCurrentFirst } from '../constants/options'
 import { useI18n } from '../i18n'
+import { PAYMENT_TERM_TYPES, PaymentTerms } from '../types/paymentTerms'
 
 export type CompanyDefaults = {
   DefaultCurrency: string
   DefaultTaxRate: number
+  DefaultWithholdingRate?: number
   DefaultFooterText?: string
+  DefaultPaymentTerms?: PaymentTerms
+  DefaultBankAccountID?: number | null
 }
 
 type Props = {
@@ -20,14 +24,18 @@ export default function CompanyDefaultsModal({ open, initial, onClose, onSubmit
   const { t } = useI18n()
   const [currency, setCurrency] = useState('USD')
   const [taxRate, setTaxRate] = useState(0)
+  const [withholdingRate, setWithholdingRate] = useState(0)
   const [footer, setFooter] = useState('')
+  const [terms, setTerms] = useState<PaymentTerms>({ Type: '', Days: 0 })
 
   useEffect(() => {
     if (!open) return
     setCurrency(initial?.DefaultCurrency ?? 'USD')
     setTaxRate(Number(initial?.DefaultTaxRate ?? 0))
+    setWithholdingRate(Number(initial?.DefaultWithholdingRate ?? 0))
     setFooter(initial?.DefaultFooterText ?? '')
-  }, [open, initial?.DefaultCurrency, initial?.DefaultTaxRate, initial?.DefaultFooterText])
+    setTerms(initial?.DefaultPaymentTerms ?? { Type: '', Days: 0 })
+  }, [open, initial?.DefaultCurrency, initial?.DefaultTaxRate, initial?.DefaultWithholdingRate, initial?.DefaultFooterText, initial?.DefaultPaymentTerms])
 
   const currencyOptions = useMemo(() => withCurrentFirst(COMMON_CURRENCIES, currency), [currency])
 
@@ -48,6 +56,34 @@ export default function CompanyDefaultsModal({ open, initial, onClose, onSubmit
             <label className="text-sm text-muted">{t('messages.defaultTaxRate')}</label>
             <input className="input" value={taxRate} onChange={(e) => setTaxRate(Number(e.target.value) || 0)} />
           </div>
+          <div className="grid gap-1">
+            <label className="text-sm text-muted">{t('messages.defaultWithholdingRate')}</label>
+            <input className="input" value={withholdingRate} onChange={(e) => setWithholdingRate(Number(e.target.value) || 0)} />
+          </div>
+          <div className="grid gap-1">
+            <label className="text-sm text-muted">{t('messages.paymentTerms')}</label>
+            <div className="grid grid-cols-3 gap-3">
+              <select
+                className="input col-span-2"
+                value={terms.Type}
+                onChange={(e) => {
+                  const type = e.target.value as PaymentTerms['Type']
+                  setTerms({ Type: type, Days: type === 'Net' ? (terms.Days || 30) : type === 'EndOfMonth' ? terms.Days : 0 })
+                }}
+              >
+                {PAYMENT_TERM_TYPES.map(tp => <option key={tp} value={tp}>{t(`messages.paymentTermsType.${tp || 'None'}`)}</option>)}
+              </select>
+              <input
+                className="input"
+                type="number"
+                min={0}
+                placeholder={t('messages.days')}
+                disabled={terms.Type !== 'Net' && terms.Type !== 'EndOfMonth'}
+                value={terms.Days}
+                onChange={(e) => setTerms({ ...terms, Days: Math.max(0, Math.trunc(Number(e.target.value) || 0)) })}
+              />
+            </div>
+          </div>
           <div className="grid gap-1">
             <label className="text-sm text-muted">{t('messages.defaultFooterText')}</label>
             <textarea className="input" rows={2} value={footer} onChange={(e) => setFooter(e.target.value)} />
@@ -55,7 +91,7 @@ export default function CompanyDefaultsModal({ open, initial, onClose, onSubmit
         </div>
         <div className="modal-actions mt-4">
           <button className="btn btn-secondary" onClick={onClose}>{t('common.cancel')}</button>
-          <button className="btn btn-primary" onClick={() => void onSubmit({ DefaultCurrency: currency, DefaultTaxRate: Number(taxRate), DefaultFooterText: footer })}>
+          <button className="btn btn-primary" onClick={() => void onSubmit({ DefaultCurrency: currency, DefaultTaxRate: Number(taxRate), DefaultWithholdingRate: Number(withholdingRate), DefaultFooterText: footer, DefaultPaymentTerms: terms })}>
             {t('common.save')}
           </button>
         </div>
@@ -2,6 +2,8 @@ import { useEffect, useMemo, useState, useCallback, ChangeEvent } from 'react'
 import Modal from './Modal'
 import { Company } from '../../bindings/github.com/fossinvoice/fossinvoice/internal/models/models.js'
 import { useI18n } from '../i18n'
+import AddressFields from './AddressFields'
+import { PostalAddress, emptyAddress, toAddress, trimAddress } from '../types/address'
 
 type Props = {
   open: boolean
@@ -16,7 +18,7 @@ export default function CompanyEditorModal({ open, initial, onClose, onSubmit, t
   const { t } = useI18n()
   const [name, setName] = useState('')
   const [taxID, setTaxID] = useState('')
-  const [address, setAddress] = useState('')
+  const [address, setAddress] = useState<PostalAddress>(emptyAddress())
   const [iconB64, setIconB64] = useState('')
   const [localSubmitting, setLocalSubmitting] = useState(false)
 
@@ -27,7 +29,7 @@ export default function CompanyEditorModal({ open, initial, onClose, onSubmit, t
     if (!open) return
     setName(initial?.Name ?? '')
     setTaxID(initial?.TaxID ?? '')
-    setAddress(initial?.Address ?? '')
+    setAddress(toAddress(initial?.Address))
     setIconB64((initial as any)?.IconB64 ?? '')
   }, [open, initial])
 
@@ -58,7 +60,7 @@ export default function CompanyEditorModal({ open, initial, onClose, onSubmit, t
       const payload = new Company({
         ID: initial?.ID ?? 0,
         Name: name.trim(),
-        Address: address.trim(),
+        Address: trimAddress(address),
         TaxID: taxID.trim(),
         IconB64: iconB64.trim(),
       })
@@ -77,7 +79,7 @@ export default function CompanyEditorModal({ open, initial, onClose, onSubmit, t
         <div className="grid gap-3 mt-3">
           <input className="input" placeholder={t('messages.name') ?? 'Name'} value={name} onChange={(e) => setName(e.target.value)} />
           <input className="input" placeholder={t('messages.taxID')} value={taxID} onChange={(e) => setTaxID(e.target.value)} />
-          <input className="input" placeholder={t('messages.address')} value={address} onChange={(e) => setAddress(e.target.value)} />
+          <AddressFields value={address} onChange={setAddress} />
           <div className="grid gap-2">
             <label className="text-sm text-muted">Icon (optional)</label>
             <div className="flex items-center gap-3">
@@ -4,14 +4,18 @@ import { faCircleInfo, faTrash } from '@fortawesome/free-solid-svg-icons'
 import Modal from './Modal'
 import { COMMON_CURRENCIES, ALLOWED_STATUSES, withCurrentFirst } from '../constants/options'
 import { translateStatus, useI18n } from '../i18n'
-import type { ClientLite, InvoiceDraft, ItemDraft } from '../types/invoice'
+import { computeDraftTotals, itemTotal } from '../types/invoice'
+import type { ClientLite, DraftDefaults, InvoiceDraft, ItemDraft } from '../types/invoice'
+import { dueDateFor } from '../types/paymentTerms'
 
 export type InvoiceEditorModalProps = {
   isOpen: boolean
   clients: ClientLite[]
   initialDraft: InvoiceDraft
   editingId: number | null
+  numberPreview?: string
   loading?: boolean
+  loadDefaults?: (clientId: number) => Promise<DraftDefaults>
   onClose: () => void
   onSubmit: (draft: InvoiceDraft) => Promise<void> | void
 }
@@ -23,11 +27,13 @@ export type InvoiceEditorModalProps = {
  * - clients: list of selectable clients
  * - initialDraft: invoice draft to edit (used to initialize local state)
  * - editingId: when not null, switches wording to Save; null => Create
+ * - numberPreview: number the invoice would get if issued now, shown while it has none
  * - loading: disables submit while saving
+ * - loadDefaults: fetches the defaults of a client, applied when another client is picked for a new invoice
  * - onClose: invoked when closing/canceling
  * - onSubmit: called with current draft when submitting
  */
-export default function InvoiceEditorModal({ isOpen, clients, initialDraft, editingId, loading = false, onClose, onSubmit }: InvoiceEditorModalProps) {
+export default function InvoiceEditorModal({ isOpen, clients, initialDraft, editingId, numberPreview = '', loading = false, loadDefaults, onClose, onSubmit }: InvoiceEditorModalProps) {
   const { t } = useI18n()
   const [draft, setDraft] = useState<InvoiceDraft>(initialDraft)
 
@@ -36,22 +42,15 @@ export default function InvoiceEditorModal({ isOpen, clients, initialDraft, edit
     setDraft({ ...initialDraft, FooterText: initialDraft.FooterText ?? '' })
   }, [initialDraft])
 
-  const computeTotals = useCallback((items: ItemDraft[], taxRate: number, discount: number) => {
-    const subtotal = items.reduce((acc, it) => acc + (it.Quantity * it.UnitPrice), 0)
-    const taxAmount = subtotal * (taxRate / 100)
-    const total = subtotal + taxAmount - (discount || 0)
-    return { subtotal, taxAmount, total }
-  }, [])
-
   const addItem = useCallback(() => {
-    setDraft(d => ({ ...d, Items: [...d.Items, { Description: '', Quantity: 1, UnitPrice: 0, Total: 0 }] }))
+    setDraft(d => ({ ...d, Items: [...d.Items, { CatalogItemID: null, Code: '', Description: '', Quantity: 1, Unit: '', UnitPrice: 0, TaxRate: null, DiscountRate: 0, DiscountAmount: 0, Total: 0 }] }))
   }, [])
 
   const updateItem = useCallback((index: number, patch: Partial<ItemDraft>) => {
     setDraft(d => {
       const items = d.Items.map((it, i) => i === index ? { ...it, ...patch } : it)
       const it = items[index]
-      it.Total = it.Quantity * it.UnitPrice
+      it.Total = itemTotal(it)
       return { ...d, Items: items }
     })
   }, [])
@@ -60,7 +59,7 @@ export default function InvoiceEditorModal({ isOpen, clients, initialDraft, edit
     setDraft(d => ({ ...d, Items: d.Items.filter((_, i) => i !== index) }))
   }, [])
 
-  const totals = useMemo(() => computeTotals(draft.Items, draft.TaxRate, draft.DiscountAmount), [computeTotals, draft.DiscountAmount, draft.Items, draft.TaxRate])
+  const totals = useMemo(() => computeDraftTotals(draft), [draft])
 
   // Searchable client combobox (same style as filter selector)
   const [clientQuery, setClientQuery] = useState('')
@@ -88,6 +87,22 @@ export default function InvoiceEditorModal({ isOpen, clients, initialDraft, edit
     return () => document.removeEventListener('mousedown', onDocClick)
   }, [])
 
+  // Picking another client for a new invoice refreshes the defaults merged from that client
+  const pickClient = useCallback((clientId: number) => {
+    setDraft(d => ({ ...d, ClientID: clientId }))
+    setClientQuery('')
+    setClientOpen(false)
+    if (editingId !== null || !loadDefaults) return
+    void loadDefaults(clientId).then(def => setDraft(d => d.ClientID !== clientId ? d : {
+      ...d,
+      Currency: def.Currency,
+      TaxRate: def.TaxRate,
+      WithholdingRate: def.WithholdingRate,
+      FooterText: def.FooterText,
+      DueDate: dueDateFor(d.IssueDate, def.PaymentTerms) || d.DueDate,
+    }))
+  }, [editingId, loadDefaults])
+
   // Common ISO 4217 currencies; ensure current value stays available
   const currencyOptions = useMemo(() => withCurrentFirst(COMMON_CURRENCIES, draft.Currency), [draft.Currency])
 
@@ -126,11 +141,7 @@ export default function InvoiceEditorModal({ isOpen, clients, initialDraft, edit
                     } else if (e.key === 'Enter') {
                       e.preventDefault()
                       const pick = visibleClients[clientHighlight]
-                      if (pick) {
-                        setDraft(d => ({ ...d, ClientID: pick.ID }))
-                        setClientQuery('')
-                        setClientOpen(false)
-                      }
+                      if (pick) pickClient(pick.ID)
                     } else if (e.key === 'Escape') {
                       e.preventDefault()
                       setClientOpen(false)
@@ -154,11 +165,7 @@ export default function InvoiceEditorModal({ isOpen, clients, initialDraft, edit
                         className={`px-2 py-1 cursor-pointer rounded ${idx === clientHighlight ? 'bg-white/10' : 'hover:bg-white/5'}`}
                         onMouseEnter={() => setClientHighlight(idx)}
                         onMouseDown={(e) => { e.preventDefault() }}
-                        onClick={() => {
-                          setDraft(d => ({ ...d, ClientID: c.ID }))
-                          setClientQuery('')
-                          setClientOpen(false)
-                        }}
+                        onClick={() => pickClient(c.ID)}
                       >
                         {c.Name}
                       </div>
@@ -174,12 +181,9 @@ export default function InvoiceEditorModal({ isOpen, clients, initialDraft, edit
               <label className="text-sm text-muted">{t('messages.invoiceNumber')}</label>
               <input
                 className="input"
-                value={draft.Number}
-                onChange={e => {
-                  const v = e.target.value
-                  const n = Number(v)
-                  setDraft({ ...draft, Number: v === '' || Number.isNaN(n) ? '' : n })
-                }}
+                readOnly
+                value={draft.DisplayNumber}
+                placeholder={numberPreview ? `${t('messages.numberOnIssue')} (${numberPreview})` : t('messages.numberOnIssue')}
               />
             </div>
             <div className="grid gap-1">
@@ -219,7 +223,7 @@ export default function InvoiceEditorModal({ isOpen, clients, initialDraft, edit
             </div>
           </div>
 
-          <div className="grid sm:grid-cols-3 gap-3">
+          <div className="grid sm:grid-cols-4 gap-3">
             <div className="grid gap-1">
               <label className="text-sm text-muted">{t('messages.defaultTaxRate')}</label>
               <input
@@ -229,13 +233,31 @@ export default function InvoiceEditorModal({ isOpen, clients, initialDraft, edit
               />
             </div>
             <div className="grid gap-1">
-              <label className="text-sm text-muted">{t('common.discount')}</label>
+              <label className="text-sm text-muted">{t('messages.withholdingRate')}</label>
               <input
                 className="input"
-                value={draft.DiscountAmount}
-                onChange={e => setDraft({ ...draft, DiscountAmount: Number(e.target.value) || 0 })}
+                value={draft.WithholdingRate}
+                onChange={e => setDraft({ ...draft, WithholdingRate: Number(e.target.value) || 0 })}
               />
             </div>
+            <div className="grid gap-1">
+              <label className="text-sm text-muted">{t('common.discount')}</label>
+              <div className="grid grid-cols-2 gap-2">
+                <input
+                  className="input"
+                  placeholder={t('common.discountRate')}
+                  title={t('common.discountRate')}
+                  value={draft.DiscountRate || ''}
+                  onChange={e => setDraft({ ...draft, DiscountRate: Number(e.target.value) || 0 })}
+                />
+                <input
+                  className="input"
+                  disabled={draft.DiscountRate > 0}
+                  value={draft.DiscountRate > 0 ? totals.discountAmount : draft.DiscountAmount}
+                  onChange={e => setDraft({ ...draft, DiscountAmount: Number(e.target.value) || 0 })}
+                />
+              </div>
+            </div>
             <div className="grid gap-1">
               <label className="text-sm text-muted">{t('common.status')}</label>
               <select
@@ -272,10 +294,12 @@ export default function InvoiceEditorModal({ isOpen, clients, initialDraft, edit
             </div>
             <div className="grid gap-2">
               {/* Header row */}
-              <div className="hidden sm:grid sm:grid-cols-[1fr_120px_120px_120px_auto] gap-2 text-xs text-muted">
+              <div className="hidden sm:grid sm:grid-cols-[1fr_90px_110px_80px_80px_110px_auto] gap-2 text-xs text-muted">
                 <div>{t('common.description')}</div>
                 <div>{t('common.qty')}</div>
                 <div>{t('common.unitPrice')}</div>
+                <div>{t('common.taxRate')}</div>
+                <div>{t('common.discountRate')}</div>
                 <div>{t('common.total')}</div>
                 <div className="text-right">{t('common.actions')}</div>
               </div>
@@ -283,11 +307,22 @@ export default function InvoiceEditorModal({ isOpen, clients, initialDraft, edit
                 <div className="text-sm text-muted">{t('common.noResults')}</div>
               )}
               {draft.Items.map((it, idx) => (
-                <div key={idx} className="grid sm:grid-cols-[1fr_120px_120px_120px_auto] gap-2 items-center">
+                <div key={idx} className="grid sm:grid-cols-[1fr_90px_110px_80px_80px_110px_auto] gap-2 items-center">
                   <input className="input" placeholder="Description" value={it.Description} onChange={e => updateItem(idx, { Description: e.target.value })} />
                   <input className="input" placeholder="Qty" value={it.Quantity} onChange={e => updateItem(idx, { Quantity: Number(e.target.value) || 0 })} />
                   <input className="input" placeholder="Unit price" value={it.UnitPrice} onChange={e => updateItem(idx, { UnitPrice: Number(e.target.value) || 0 })} />
-                  <div className="text-sm">{(it.Quantity * it.UnitPrice).toFixed(2)}</div>
+                  <input
+                    className="input"
+                    placeholder={String(draft.TaxRate)}
+                    value={it.TaxRate ?? ''}
+                    onChange={e => updateItem(idx, { TaxRate: e.target.value.trim() === '' ? null : Number(e.target.value) || 0 })}
+                  />
+                  <input
+                    className="input"
+                    value={it.DiscountRate || ''}
+                    onChange={e => updateItem(idx, { DiscountRate: Number(e.target.value) || 0, DiscountAmount: 0 })}
+                  />
+                  <div className="text-sm">{itemTotal(it).toFixed(2)}</div>
                   <button className="btn btn-secondary" onClick={() => removeItem(idx)} aria-label={t('common.removeItem')} title={t('common.removeItem')}>
                     <FontAwesomeIcon icon={faTrash} />
                   </button>
@@ -296,16 +331,17 @@ export default function InvoiceEditorModal({ isOpen, clients, initialDraft, edit
             </div>
           </div>
 
-          <div className="grid sm:grid-cols-3 gap-3 text-sm">
+          <div className="grid sm:grid-cols-4 gap-3 text-sm">
             <div className="grid gap-1"><div className="text-muted">{t('common.subtotal')}</div><div className="font-medium">{totals.subtotal.toFixed(2)}</div></div>
             <div className="grid gap-1"><div className="text-muted">{t('common.tax')}</div><div className="font-medium">{totals.taxAmount.toFixed(2)}</div></div>
+            <div className="grid gap-1"><div className="text-muted">{t('messages.withholding')}</div><div className="font-medium">{(-totals.withholdingAmount).toFixed(2)}</div></div>
             <div className="grid gap-1"><div className="text-muted">{t('common.total')}</div><div className="font-medium">{totals.total.toFixed(2)}</div></div>
           </div>
         </div>
 
         <div className="modal-actions mt-4">
           <button className="btn btn-secondary" onClick={onClose}>{t('common.cancel')}</button>
-          <button className="btn btn-primary" onClick={() => void onSubmit(draft)} disabled={loading || draft.Number === ''}>
+          <button className="btn btn-primary" onClick={() => void onSubmit(draft)} disabled={loading}>
             {editingId ? t('common.save') : t('common.create')}
           </button>
         </div>
@@ -7,7 +7,7 @@ export const COMMON_CURRENCIES = [
 
 // Allowed invoice statuses
 export const ALLOWED_STATUSES = [
-  'Draft', 'Pending', 'Sent', 'Paid', 'Void',
+  'Draft', 'Sent', 'PartiallyPaid', 'Paid', 'Void',
 ] as const
 
 // Ensures the current value appears in the options list (without duplication)
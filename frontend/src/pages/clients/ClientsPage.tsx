@@ -9,11 +9,13 @@ import { Client, ContactInfo } from '../../../bindings/github.com/fossinvoice/fo
 import { DatabaseService } from '../../../bindings/github.com/fossinvoice/fossinvoice/internal/services'
 import { useToast } from '../../context/ToastContext'
 import { useI18n } from '../../i18n'
+import AddressFields from '../../components/AddressFields'
+import { PostalAddress, emptyAddress, formatAddress, toAddress, trimAddress } from '../../types/address'
 
 type ClientDraft = {
   ID?: number
   Name: string
-  Address: string
+  Address: PostalAddress
   TaxID: string
   Email: string
   Phone: string
@@ -39,7 +41,7 @@ export default function ClientsPage() {
 
   const [showModal, setShowModal] = useState(false)
   const [editing, setEditing] = useState<Client | null>(null)
-  const [draft, setDraft] = useState<ClientDraft>({ Name: '', Address: '', TaxID: '', Email: '', Phone: '', Website: '' })
+  const [draft, setDraft] = useState<ClientDraft>({ Name: '', Address: emptyAddress(), TaxID: '', Email: '', Phone: '', Website: '' })
 
   const effectiveCompanyId = useMemo(() => {
     const fromRoute = companyId ? Number(companyId) : null
@@ -95,7 +97,7 @@ export default function ClientsPage() {
 
   const openCreate = useCallback(() => {
     setEditing(null)
-    setDraft({ Name: '', Address: '', TaxID: '', Email: '', Phone: '', Website: '' })
+    setDraft({ Name: '', Address: emptyAddress(), TaxID: '', Email: '', Phone: '', Website: '' })
     setShowModal(true)
   }, [])
 
@@ -104,7 +106,7 @@ export default function ClientsPage() {
     setDraft({
       ID: c.ID,
       Name: c.Name ?? '',
-      Address: c.Address ?? '',
+      Address: toAddress(c.Address),
       TaxID: c.TaxID ?? '',
       Email: c.Contact?.Email ?? '',
       Phone: c.Contact?.Phone ?? '',
@@ -127,7 +129,7 @@ export default function ClientsPage() {
         ID: draft.ID ?? 0,
         CompanyID: effectiveCompanyId,
         Name: draft.Name.trim(),
-        Address: draft.Address.trim(),
+        Address: trimAddress(draft.Address),
         TaxID: draft.TaxID.trim(),
         Contact: new ContactInfo({
           Email: draft.Email.trim() || null,
@@ -137,11 +139,11 @@ export default function ClientsPage() {
       })
 
       if (editing) {
-        const updated = await DatabaseService.UpdateClient(databasePath, payload as any)
+        const updated = await DatabaseService.UpdateClient(databasePath, payload)
         if (!updated) throw new Error(t('messages.failedUpdateClient'))
         toast.success(t('messages.clientUpdated'))
       } else {
-        const created = await DatabaseService.CreateClient(databasePath, effectiveCompanyId, payload as any)
+        const created = await DatabaseService.CreateClient(databasePath, effectiveCompanyId, payload)
         if (!created) throw new Error(t('messages.failedCreateClient'))
         toast.success(t('messages.clientCreated'))
       }
@@ -195,7 +197,7 @@ export default function ClientsPage() {
             <li key={c.ID} className="card p-4 flex flex-col gap-2">
               <div className="font-medium truncate">{c.Name}</div>
               <div className="text-xs text-muted truncate">{t('messages.taxID')}: {c.TaxID || '—'}</div>
-              <div className="text-xs text-muted truncate">{t('messages.address')}: {c.Address || '—'}</div>
+              <div className="text-xs text-muted truncate">{t('messages.address')}: {formatAddress(c.Address) || '—'}</div>
               <div className="mt-2 flex gap-2">
                 <button
                   className="icon-btn"
@@ -232,7 +234,7 @@ export default function ClientsPage() {
             <div className="grid gap-3 mt-3">
               <input className="input" placeholder={t('messages.name') ?? 'Name'} value={draft.Name} onChange={(e) => setDraft({ ...draft, Name: e.target.value })} />
               <input className="input" placeholder={t('messages.taxID')} value={draft.TaxID} onChange={(e) => setDraft({ ...draft, TaxID: e.target.value })} />
-              <input className="input" placeholder={t('messages.address')} value={draft.Address} onChange={(e) => setDraft({ ...draft, Address: e.target.value })} />
+              <AddressFields value={draft.Address} onChange={(Address) => setDraft({ ...draft, Address })} />
               <div className="grid sm:grid-cols-3 gap-3">
                 <input className="input" placeholder={t('messages.email')} value={draft.Email} onChange={(e) => setDraft({ ...draft, Email: e.target.value })} />
                 <input className="input" placeholder={t('messages.phone')} value={draft.Phone} onChange={(e) => setDraft({ ...draft, Phone: e.target.value })} />
@@ -6,10 +6,11 @@ import { useSelectedCompany } from '../../context/SelectedCompanyContext'
 import { useDatabasePath } from '../../context/DatabasePathContext'
 import { DatabaseService } from '../../../bindings/github.com/fossinvoice/fossinvoice/internal/services'
 import { Company } from '../../../bindings/github.com/fossinvoice/fossinvoice/internal/models/models.js'
-import CompanyDefaultsModal from '../../components/CompanyDefaultsModal'
+import CompanyDefaultsModal, { CompanyDefaults as CompanyDefaultsValues } from '../../components/CompanyDefaultsModal'
 import CompanyContactModal from '../../components/CompanyContactModal'
 import CompanyEditorModal from '../../components/CompanyEditorModal'
 import { useI18n } from '../../i18n'
+import { formatAddress } from '../../types/address'
 
 export default function CompanyInfo() {
   const { t } = useI18n()
@@ -24,7 +25,7 @@ export default function CompanyInfo() {
   const [showDefaults, setShowDefaults] = useState(false)
   const [showContactModal, setShowContactModal] = useState(false)
   const [defaultsLoading, setDefaultsLoading] = useState(false)
-  const [defaults, setDefaults] = useState<{ DefaultCurrency: string; DefaultTaxRate: number; DefaultFooterText?: string } | null>(null)
+  const [defaults, setDefaults] = useState<CompanyDefaultsValues | null>(null)
 
   const effectiveId = useMemo(() => {
     const fromRoute = companyId ? Number(companyId) : null
@@ -55,7 +56,7 @@ export default function CompanyInfo() {
     setDefaultsLoading(true)
     try {
   const def = await DatabaseService.GetCompanyDefaults(databasePath, effectiveId)
-    setDefaults({ DefaultCurrency: def?.DefaultCurrency ?? 'USD', DefaultTaxRate: Number(def?.DefaultTaxRate ?? 0), DefaultFooterText: (def as any)?.DefaultFooterText ?? '' })
+    setDefaults({ DefaultCurrency: def?.DefaultCurrency ?? 'USD', DefaultTaxRate: Number(def?.DefaultTaxRate ?? 0), DefaultWithholdingRate: Number(def?.DefaultWithholdingRate ?? 0), DefaultFooterText: (def as any)?.DefaultFooterText ?? '', DefaultPaymentTerms: (def as any)?.DefaultPaymentTerms, DefaultBankAccountID: (def as any)?.DefaultBankAccountID ?? null })
     } finally {
       setDefaultsLoading(false)
     }
@@ -162,7 +163,7 @@ export default function CompanyInfo() {
             </div>
             <div className="sm:col-span-2">
               <div className="text-muted">{t('messages.address')}</div>
-              <div className="font-medium">{company.Address || '—'}</div>
+              <div className="font-medium">{formatAddress(company.Address) || '—'}</div>
             </div>
           </div>
         </div>
@@ -231,6 +232,10 @@ export default function CompanyInfo() {
                 <div className="text-muted">{t('messages.defaultTaxRate')}</div>
                 <div className="font-medium">{Number(defaults?.DefaultTaxRate ?? 0)}%</div>
               </div>
+              <div>
+                <div className="text-muted">{t('messages.defaultWithholdingRate')}</div>
+                <div className="font-medium">{Number(defaults?.DefaultWithholdingRate ?? 0)}%</div>
+              </div>
               <div className="sm:col-span-3">
                 <div className="text-muted">{t('messages.defaultFooterText')}</div>
                 <div className="font-medium whitespace-pre-wrap">{defaults?.DefaultFooterText ?? ''}</div>
@@ -266,7 +271,7 @@ export default function CompanyInfo() {
           onClose={() => setShowDefaults(false)}
           onSubmit={async (vals) => {
             if (!databasePath || !effectiveId) return
-            await DatabaseService.UpdateCompanyDefaults(databasePath, { CompanyID: effectiveId, DefaultCurrency: vals.DefaultCurrency, DefaultTaxRate: Number(vals.DefaultTaxRate), DefaultFooterText: (vals as any)?.DefaultFooterText ?? '' } as any)
+            await DatabaseService.UpdateCompanyDefaults(databasePath, { CompanyID: effectiveId, DefaultCurrency: vals.DefaultCurrency, DefaultTaxRate: Number(vals.DefaultTaxRate), DefaultWithholdingRate: Number(vals.DefaultWithholdingRate ?? 0), DefaultFooterText: (vals as any)?.DefaultFooterText ?? '', DefaultPaymentTerms: vals.DefaultPaymentTerms ?? { Type: '', Days: 0 }, DefaultBankAccountID: defaults?.DefaultBankAccountID ?? null } as any)
             setShowDefaults(false)
             await loadDefaults()
           }}
@@ -4,7 +4,10 @@ import { faPen, faTrash, faFilePdf } from '@fortawesome/free-solid-svg-icons'
 import { useParams } from 'react-router-dom'
 import { useSelectedCompany } from '../../context/SelectedCompanyContext'
 import { useDatabasePath } from '../../context/DatabasePathContext'
-import type { ClientLite, InvoiceDraft, ItemDraft } from '../../types/invoice'
+import { computeDraftTotals } from '../../types/invoice'
+import type { ClientLite, DraftDefaults, InvoiceDraft } from '../../types/invoice'
+import { dueDateFor } from '../../types/paymentTerms'
+import type { PaymentTerms } from '../../types/paymentTerms'
 import InvoiceEditorModal from '../../components/InvoiceEditorModal'
 import { DatabaseService, DialogsService, PDFService } from '../../../bindings/github.com/fossinvoice/fossinvoice/internal/services'
 import { translateStatus } from '../../i18n'
@@ -53,6 +56,7 @@ export default function InvoicesPage() {
   const [showModal, setShowModal] = useState(false)
   const [editingId, setEditingId] = useState<number | null>(null)
   const [draft, setDraft] = useState<InvoiceDraft | null>(null)
+  const [numberPreview, setNumberPreview] = useState('')
 
   // Using generated static bindings
 
@@ -89,13 +93,6 @@ export default function InvoicesPage() {
     return () => document.removeEventListener('mousedown', onDocClick)
   }, [])
 
-  const computeTotals = useCallback((items: ItemDraft[], taxRate: number, discount: number) => {
-    const subtotal = items.reduce((acc, it) => acc + (it.Quantity * it.UnitPrice), 0)
-    const taxAmount = subtotal * (taxRate / 100)
-    const total = subtotal + taxAmount - (discount || 0)
-    return { subtotal, taxAmount, total }
-  }, [])
-
   // Load clients for filter and selection
   const loadClients = useCallback(async () => {
     if (!databasePath || !effectiveCompanyId) return
@@ -120,7 +117,7 @@ export default function InvoicesPage() {
       // Try server-side pagination if available in bindings
       const svc: any = DatabaseService as any
       if (typeof svc.ListInvoicesPaged === 'function') {
-        const resp = await svc.ListInvoicesPaged(databasePath, effectiveCompanyId, fy, cid, PAGE_SIZE, (page - 1) * PAGE_SIZE)
+        const resp = await svc.ListInvoicesPaged(databasePath, effectiveCompanyId, fy, cid, false, PAGE_SIZE, (page - 1) * PAGE_SIZE)
         const items = resp?.Items ?? resp?.items ?? []
         const total = Number(resp?.Total ?? resp?.total ?? items.length)
         setInvoices(items)
@@ -176,61 +173,69 @@ export default function InvoicesPage() {
   }, [invoices, page])
   const displayedInvoices = serverPaged ? invoices : paginatedInvoices
 
-  const openCreate = useCallback(async () => {
-    if (!effectiveCompanyId) return
-    setEditingId(null)
-    // Determine next invoice number: try backend service, else compute from loaded list
-    let nextNumber = 1
-    let defaultCurrency = 'USD'
-    let defaultTaxRate = 0
-    let defaultFooterText = ''
+  // Number the next issued invoice gets: from the company's default series, else the next plain number.
+  // Drafts are numbered by the backend when they leave Draft, so this is only a preview.
+  const loadNumberPreview = useCallback(async (fiscalYear: number): Promise<string> => {
+    if (!databasePath || !effectiveCompanyId) return ''
     try {
-      if (databasePath) {
-        const max: any = await DatabaseService.GetMaxInvoiceNumber(databasePath, effectiveCompanyId)
-        const n = typeof max === 'number' ? max : Number(max)
-        if (Number.isFinite(n) && n >= 0) nextNumber = n + 1
-      } else {
-        // Fallback: compute from current invoices list (numeric-only)
-        const maxLocal = invoices
-          .filter(inv => inv.CompanyID === effectiveCompanyId)
-          .reduce((acc, inv) => Math.max(acc, Number(inv.Number ?? 0)), 0)
-        nextNumber = maxLocal + 1
-      }
+      const series = (await DatabaseService.ListNumberingSeries(databasePath, effectiveCompanyId)) ?? []
+      const def = series.find(s => s.DocumentType === 'Invoice' && s.IsDefault)
+      if (def) return await DatabaseService.PreviewNextNumber(databasePath, def.ID, fiscalYear)
+      const max = Number(await DatabaseService.GetMaxInvoiceNumber(databasePath, effectiveCompanyId))
+      return Number.isFinite(max) ? String(max + 1) : ''
+    } catch {
+      return ''
+    }
+  }, [databasePath, effectiveCompanyId])
 
-      // Try to fetch company defaults for currency and tax rate
-      if (databasePath) {
-        const def: any = await DatabaseService.GetCompanyDefaults(databasePath, effectiveCompanyId)
-        if (def) {
-          if (typeof def.DefaultCurrency === 'string' && def.DefaultCurrency.trim()) defaultCurrency = def.DefaultCurrency
-          if (typeof def.DefaultTaxRate === 'number' && Number.isFinite(def.DefaultTaxRate)) defaultTaxRate = def.DefaultTaxRate
-          if (typeof def.DefaultFooterText === 'string') defaultFooterText = def.DefaultFooterText
-        }
+  // Company defaults (with the client's own defaults merged on top) prefilled into a new invoice
+  const loadDraftDefaults = useCallback(async (clientId: number): Promise<DraftDefaults> => {
+    const out: DraftDefaults = { Currency: 'USD', TaxRate: 0, WithholdingRate: 0, FooterText: '', PaymentTerms: null }
+    if (!databasePath || !effectiveCompanyId) return out
+    try {
+      const def = await DatabaseService.GetInvoiceDefaults(databasePath, effectiveCompanyId, clientId)
+      if (def) {
+        if (def.Currency.trim()) out.Currency = def.Currency
+        out.TaxRate = Number(def.TaxRate ?? 0)
+        out.WithholdingRate = Number(def.WithholdingRate ?? 0)
+        out.FooterText = def.FooterText
+        if (def.PaymentTerms?.Type) out.PaymentTerms = def.PaymentTerms as PaymentTerms
       }
     } catch {
-      // Ignore errors and keep default '1'
-      const maxLocal = invoices
-        .filter((inv: any) => inv.CompanyID === effectiveCompanyId)
-        .reduce((acc: number, inv: any) => Math.max(acc, Number(inv.Number ?? 0)), 0)
-      nextNumber = maxLocal + 1
+      // Ignore errors and keep the built-in defaults
     }
+    return out
+  }, [databasePath, effectiveCompanyId])
+
+  const openCreate = useCallback(async () => {
+    if (!effectiveCompanyId) return
+    setEditingId(null)
+    const fiscalYear = filterFiscalYear || new Date().getFullYear()
+    setNumberPreview(await loadNumberPreview(fiscalYear))
+    const clientId = filterClientID || (clients[0]?.ID ?? 0)
+    const def = await loadDraftDefaults(clientId)
 
+    const today = new Date().toISOString().slice(0, 10)
     setDraft({
       CompanyID: effectiveCompanyId,
-      ClientID: filterClientID || (clients[0]?.ID ?? 0),
-      Number: nextNumber,
-      FiscalYear: filterFiscalYear || new Date().getFullYear(),
-      IssueDate: new Date().toISOString().slice(0, 10),
-      DueDate: new Date().toISOString().slice(0, 10),
-      Currency: defaultCurrency,
-      TaxRate: defaultTaxRate,
+      ClientID: clientId,
+      Number: 0,
+      DisplayNumber: '',
+      FiscalYear: fiscalYear,
+      IssueDate: today,
+      DueDate: dueDateFor(today, def.PaymentTerms) || today,
+      Currency: def.Currency,
+      TaxRate: def.TaxRate,
+      WithholdingRate: def.WithholdingRate,
+      DiscountRate: 0,
       DiscountAmount: 0,
       Status: 'Draft',
       Notes: '',
-      FooterText: defaultFooterText,
+      FooterText: def.FooterText,
       Items: [],
     })
     setShowModal(true)
-  }, [clients, databasePath, effectiveCompanyId, filterClientID, filterFiscalYear, invoices])
+  }, [clients, effectiveCompanyId, filterClientID, filterFiscalYear, loadDraftDefaults, loadNumberPreview])
 
   const openEdit = useCallback(async (id: number) => {
     if (!databasePath) return
@@ -241,26 +246,37 @@ export default function InvoicesPage() {
       if (!inv) {
         throw new Error('Invoice not found')
       }
+      const displayNumber = inv.DisplayNumber ?? ''
+      setNumberPreview(displayNumber ? '' : await loadNumberPreview(inv.FiscalYear || new Date().getFullYear()))
       setEditingId(id)
       setDraft({
         ID: inv.ID,
         CompanyID: inv.CompanyID,
         ClientID: inv.ClientID,
         Number: typeof inv.Number === 'number' ? inv.Number : Number(inv.Number ?? 0),
+        DisplayNumber: displayNumber,
         FiscalYear: inv.FiscalYear ?? '',
         IssueDate: inv.IssueDate ?? '',
         DueDate: inv.DueDate ?? '',
         Currency: inv.Currency ?? 'USD',
         TaxRate: inv.TaxRate ?? 0,
+        WithholdingRate: Number(inv.WithholdingRate ?? 0),
+        DiscountRate: Number(inv.DiscountRate ?? 0),
         DiscountAmount: inv.DiscountAmount ?? 0,
         Status: inv.Status ?? 'Draft',
         Notes: inv.Notes ?? '',
-        FooterText: (inv as any).FooterText ?? '',
+        FooterText: inv.FooterText ?? '',
         Items: (inv.Items ?? []).map((it: any) => ({
           ID: it.ID,
+          CatalogItemID: it.CatalogItemID ?? null,
+          Code: it.Code ?? '',
           Description: it.Description ?? '',
           Quantity: Number(it.Quantity ?? 0),
+          Unit: it.Unit ?? '',
           UnitPrice: Number(it.UnitPrice ?? 0),
+          TaxRate: it.TaxRate == null ? null : Number(it.TaxRate),
+          DiscountRate: Number(it.DiscountRate ?? 0),
+          DiscountAmount: Number(it.DiscountAmount ?? 0),
           Total: Number(it.Total ?? 0),
         })),
       })
@@ -272,7 +288,7 @@ export default function InvoicesPage() {
     } finally {
       setLoading(false)
     }
-  }, [databasePath])
+  }, [databasePath, loadNumberPreview])
 
   const closeModal = useCallback(() => {
     setShowModal(false)
@@ -284,17 +300,16 @@ export default function InvoicesPage() {
 
   const submit = useCallback(async (subDraft: InvoiceDraft) => {
     if (!databasePath || !subDraft) return
-    if (subDraft.Number === '') return
     const fy = subDraft.FiscalYear === '' ? 0 : Number(subDraft.FiscalYear)
     setLoading(true)
     setError(null)
     try {
-      const totals = computeTotals(subDraft.Items, subDraft.TaxRate, subDraft.DiscountAmount)
+      const totals = computeDraftTotals(subDraft)
       const payload = {
         ID: subDraft.ID ?? 0,
         CompanyID: subDraft.CompanyID,
         ClientID: subDraft.ClientID,
-        Number: Number(subDraft.Number),
+        Number: subDraft.Number,
         FiscalYear: fy,
         IssueDate: subDraft.IssueDate,
         DueDate: subDraft.DueDate,
@@ -302,16 +317,25 @@ export default function InvoicesPage() {
         Subtotal: totals.subtotal,
         TaxRate: subDraft.TaxRate,
         TaxAmount: totals.taxAmount,
-        DiscountAmount: subDraft.DiscountAmount,
+        WithholdingRate: subDraft.WithholdingRate,
+        WithholdingAmount: totals.withholdingAmount,
+        DiscountRate: subDraft.DiscountRate,
+        DiscountAmount: totals.discountAmount,
         Total: totals.total,
         Status: subDraft.Status,
         Notes: subDraft.Notes ? subDraft.Notes : null,
         FooterText: subDraft.FooterText ?? '',
         Items: subDraft.Items.map(it => ({
           ID: it.ID ?? 0,
+          CatalogItemID: it.CatalogItemID,
+          Code: it.Code,
           Description: it.Description,
           Quantity: it.Quantity,
+          Unit: it.Unit,
           UnitPrice: it.UnitPrice,
+          TaxRate: it.TaxRate,
+          DiscountRate: it.DiscountRate,
+          DiscountAmount: it.DiscountAmount,
           Total: it.Total,
         })),
       }
@@ -333,7 +357,7 @@ export default function InvoicesPage() {
     } finally {
       setLoading(false)
     }
-  }, [closeModal, computeTotals, databasePath, editingId, loadInvoices, loadFiscalYears])
+  }, [closeModal, databasePath, editingId, loadInvoices, loadFiscalYears])
 
   const remove = useCallback(async (id: number) => {
     if (!databasePath) return
@@ -363,7 +387,7 @@ export default function InvoicesPage() {
       if (!resp || !resp.Path) { return } // cancelled
 
   // Call backend to generate (pass current locale for PDF i18n)
-  await PDFService.ExportInvoicePDF(databasePath, inv.ID, resp.Path, locale)
+  await PDFService.ExportInvoicePDF(databasePath, inv.ID, resp.Path, locale, false)
   setSuccess(t('messages.pdfExported'))
   toast.success(t('messages.pdfExported'))
       // Auto clear success after a moment
@@ -571,7 +595,7 @@ export default function InvoicesPage() {
             <tbody>
               {displayedInvoices.map((inv: any) => (
                 <tr key={inv.ID} className="border-t" style={{ borderColor: 'var(--color-surface-border)' }}>
-                  <td className="py-2 pr-3">{String(inv.Number ?? '')}</td>
+                  <td className="py-2 pr-3">{inv.DisplayNumber || (inv.Number ? String(inv.Number) : '—')}</td>
                   <td className="py-2 pr-3">{clientsMap.get(inv.ClientID) ?? inv.ClientID}</td>
                   <td className="py-2 pr-3">{inv.FiscalYear ?? '—'}</td>
                   <td className="py-2 pr-3">{inv.IssueDate}</td>
@@ -601,6 +625,7 @@ export default function InvoicesPage() {
                         className="icon-btn"
                         aria-label={t('common.delete')}
                         title={t('common.delete')}
+                        disabled={!!inv.Status && inv.Status !== 'Draft'}
                         onClick={() => void remove(inv.ID)}
                       >
                         <FontAwesomeIcon icon={faTrash} />
@@ -638,7 +663,9 @@ export default function InvoicesPage() {
           clients={clients}
           initialDraft={draft}
           editingId={editingId}
+          numberPreview={numberPreview}
           loading={loading}
+          loadDefaults={loadDraftDefaults}
           onClose={closeModal}
           onSubmit={submit}
         />
@@ -0,0 +1,42 @@
+export type PostalAddress = {
+  Line1: string
+  Line2: string
+  PostalCode: string
+  City: string
+  Region: string
+  Country: string
+}
+
+export const emptyAddress = (): PostalAddress => ({ Line1: '', Line2: '', PostalCode: '', City: '', Region: '', Country: '' })
+
+// toAddress normalizes an address coming from the backend (older data may still be a plain string).
+export function toAddress(a: any): PostalAddress {
+  if (!a) return emptyAddress()
+  if (typeof a === 'string') return { ...emptyAddress(), Line1: a }
+  return {
+    Line1: a.Line1 ?? '',
+    Line2: a.Line2 ?? '',
+    PostalCode: a.PostalCode ?? '',
+    City: a.City ?? '',
+    Region: a.Region ?? '',
+    Country: a.Country ?? '',
+  }
+}
+
+export function trimAddress(a: PostalAddress): PostalAddress {
+  return {
+    Line1: a.Line1.trim(),
+    Line2: a.Line2.trim(),
+    PostalCode: a.PostalCode.trim(),
+    City: a.City.trim(),
+    Region: a.Region.trim(),
+    Country: a.Country.trim().toUpperCase(),
+  }
+}
+
+// formatAddress returns a one-line summary of an address, e.g. "Via Roma 1, 00184 Roma, IT".
+export function formatAddress(a: any): string {
+  const v = toAddress(a)
+  const city = [v.PostalCode, v.City].filter((s) => s.trim()).join(' ')
+  return [v.Line1, v.Line2, city, v.Region, v.Country].filter((s) => s.trim()).join(', ')
+}
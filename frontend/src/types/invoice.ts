@@ -1,26 +1,88 @@
+import type { PaymentTerms } from './paymentTerms'
+
 export type ClientLite = { ID: number; Name: string }
 
 export type ItemDraft = {
   ID?: number
+  CatalogItemID: number | null // catalog entry the line was picked from, if any
+  Code: string
   Description: string
   Quantity: number
+  Unit: string // UN/ECE Rec 20 code, e.g. 'HUR'; '' for none
   UnitPrice: number
-  Total: number
+  TaxRate: number | null // own rate in percent; null uses the invoice rate
+  DiscountRate: number // percent; when 0, DiscountAmount is used as is
+  DiscountAmount: number
+  Total: number // after the line discount
 }
 
 export type InvoiceDraft = {
   ID?: number
   CompanyID: number
   ClientID: number
-  Number: number | ''
+  Number: number // 0 until the invoice is issued, unless numbered by hand
+  DisplayNumber: string
   FiscalYear: number | ''
   IssueDate: string
   DueDate: string
   Currency: string
   TaxRate: number
+  WithholdingRate: number
+  DiscountRate: number // percent of the subtotal; when 0, DiscountAmount is used as is
   DiscountAmount: number
   Status: string
   Notes: string
   FooterText: string
   Items: ItemDraft[]
 }
+
+// DraftDefaults are the company defaults, with the client's own merged on top, prefilled into a new invoice.
+export type DraftDefaults = {
+  Currency: string
+  TaxRate: number
+  WithholdingRate: number
+  FooterText: string
+  PaymentTerms: PaymentTerms | null
+}
+
+// itemTaxRate mirrors models.Invoice.ItemTaxRate: the item's own rate, otherwise the invoice rate.
+export function itemTaxRate(it: ItemDraft, invoiceRate: number): number {
+  return it.TaxRate ?? invoiceRate
+}
+
+const round = (n: number) => Math.round(n * 100) / 100
+
+// discountOf mirrors models.discountOf: rate percent of amount when a rate is set, otherwise the
+// absolute discount, with the sign of amount.
+export function discountOf(amount: number, rate: number, absolute: number): number {
+  if (rate > 0) return round(amount * rate / 100)
+  const d = round(Math.abs(absolute || 0))
+  return amount < 0 ? -d : d
+}
+
+// itemTotal returns the line total after its discount.
+export function itemTotal(it: ItemDraft): number {
+  const gross = round(it.Quantity * it.UnitPrice)
+  return gross - discountOf(gross, it.DiscountRate, it.DiscountAmount)
+}
+
+// computeDraftTotals previews models.Invoice.ComputeTotals for the editor; the backend recomputes on save.
+export function computeDraftTotals(draft: Pick<InvoiceDraft, 'Items' | 'TaxRate' | 'DiscountRate' | 'DiscountAmount' | 'WithholdingRate'>) {
+  let subtotal = 0
+  const bases = new Map<number, number>()
+  for (const it of draft.Items) {
+    const total = itemTotal(it)
+    const rate = itemTaxRate(it, draft.TaxRate)
+    subtotal += total
+    bases.set(rate, (bases.get(rate) ?? 0) + total)
+  }
+  const discount = discountOf(subtotal, draft.DiscountRate, draft.DiscountAmount)
+  let taxAmount = 0
+  for (const [rate, base] of bases) {
+    const share = subtotal ? discount * base / subtotal : 0
+    taxAmount += round((base - share) * rate / 100)
+  }
+  const withholdingAmount = round((subtotal - discount) * (draft.WithholdingRate || 0) / 100)
+  const total = subtotal - discount + taxAmount - withholdingAmount
+  return { subtotal, discountAmount: discount, taxAmount, withholdingAmount, total }
+}
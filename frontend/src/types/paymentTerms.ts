@@ -0,0 +1,25 @@
+export type PaymentTerms = {
+  Type: '' | 'Net' | 'EndOfMonth' | 'OnReceipt'
+  Days: number
+}
+
+export const PAYMENT_TERM_TYPES: PaymentTerms['Type'][] = ['', 'Net', 'EndOfMonth', 'OnReceipt']
+
+// dueDateFor mirrors models.PaymentTerms.DueDate: the ISO due date for an ISO issue date, or '' without terms.
+export function dueDateFor(issueDate: string, terms?: PaymentTerms | null): string {
+  if (!terms || !terms.Type) return ''
+  const [y, m, d] = issueDate.split('-').map(Number)
+  if (!y || !m || !d) return ''
+  let due: Date
+  switch (terms.Type) {
+    case 'Net':
+      due = new Date(Date.UTC(y, m - 1, d + terms.Days))
+      break
+    case 'EndOfMonth':
+      due = new Date(Date.UTC(y, m, 0 + terms.Days))
+      break
+    default:
+      return issueDate
+  }
+  return due.toISOString().slice(0, 10)
+}
@@ -24,12 +24,40 @@ func Open(dbPath string) (*Database, error) {
 		return nil, err
 	}
 
+	if err := migrateDecimalColumns(gdb); err != nil {
+		return nil, err
+	}
+	if err := migrateDisplayNumbers(gdb); err != nil {
+		return nil, err
+	}
+	if err := migrateAmountCredited(gdb); err != nil {
+		return nil, err
+	}
+	if err := migrateAddresses(gdb); err != nil {
+		return nil, err
+	}
+
 	if err := gdb.AutoMigrate(
 		&models.Company{},
 		&models.Client{},
+		&models.ClientContact{},
+		&models.ClientAddress{},
 		&models.Invoice{},
 		&models.InvoiceItem{},
+		&models.InvoiceTaxLine{},
+		&models.Payment{},
 		&models.CompanyDefaults{},
+		&models.ClientDefaults{},
+		&models.NumberingSeries{},
+		&models.NumberingCounter{},
+		&models.RecurringInvoice{},
+		&models.RecurringInvoiceItem{},
+		&models.CatalogItem{},
+		&models.ExchangeRate{},
+		&models.BankAccount{},
+		&models.Expense{},
+		&models.TimeEntry{},
+		&models.Attachment{},
 	); err != nil {
 		return nil, err
 	}
@@ -0,0 +1,100 @@
+package db
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/fossinvoice/fossinvoice/internal/models"
+)
+
+// baselineSchema is the schema of the first release, before amounts were Decimal and before
+// numbering, credit notes or structured addresses existed.
+var baselineSchema = []string{
+	"CREATE TABLE `companies` (`id` integer PRIMARY KEY AUTOINCREMENT,`created_at` datetime,`updated_at` datetime,`deleted_at` datetime,`name` text,`address` text,`tax_id` text,`icon_b64` text,`email` text,`phone` text,`website` text)",
+	"CREATE INDEX `idx_companies_deleted_at` ON `companies`(`deleted_at`)",
+	"CREATE TABLE `clients` (`id` integer PRIMARY KEY AUTOINCREMENT,`created_at` datetime,`updated_at` datetime,`deleted_at` datetime,`company_id` integer,`name` text,`address` text,`tax_id` text,`email` text,`phone` text,`website` text,CONSTRAINT `fk_companies_clients` FOREIGN KEY (`company_id`) REFERENCES `companies`(`id`))",
+	"CREATE INDEX `idx_clients_deleted_at` ON `clients`(`deleted_at`)",
+	"CREATE TABLE `invoices` (`id` integer PRIMARY KEY AUTOINCREMENT,`created_at` datetime,`updated_at` datetime,`deleted_at` datetime,`company_id` integer,`client_id` integer,`number` integer,`issue_date` text,`due_date` text,`fiscal_year` integer,`currency` text,`subtotal` real,`tax_rate` real,`tax_amount` real,`discount_amount` real,`total` real,`status` text,`notes` text,`footer_text` text,CONSTRAINT `fk_clients_invoices` FOREIGN KEY (`client_id`) REFERENCES `clients`(`id`),CONSTRAINT `fk_companies_invoices` FOREIGN KEY (`company_id`) REFERENCES `companies`(`id`))",
+	"CREATE INDEX `idx_invoices_deleted_at` ON `invoices`(`deleted_at`)",
+	"CREATE TABLE `invoice_items` (`id` integer PRIMARY KEY AUTOINCREMENT,`created_at` datetime,`updated_at` datetime,`deleted_at` datetime,`invoice_id` integer,`description` text,`quantity` real,`unit_price` real,`total` real,CONSTRAINT `fk_invoices_items` FOREIGN KEY (`invoice_id`) REFERENCES `invoices`(`id`))",
+	"CREATE INDEX `idx_invoice_items_deleted_at` ON `invoice_items`(`deleted_at`)",
+	"CREATE TABLE `company_defaults` (`id` integer PRIMARY KEY AUTOINCREMENT,`created_at` datetime,`updated_at` datetime,`deleted_at` datetime,`company_id` integer,`default_currency` text,`default_tax_rate` real,`default_footer_text` text,CONSTRAINT `fk_company_defaults_company` FOREIGN KEY (`company_id`) REFERENCES `companies`(`id`))",
+	"CREATE UNIQUE INDEX `idx_company_defaults_company_id` ON `company_defaults`(`company_id`)",
+	"CREATE INDEX `idx_company_defaults_deleted_at` ON `company_defaults`(`deleted_at`)",
+	"INSERT INTO `companies` (`name`, `address`) VALUES ('Acme', 'Main St 1')",
+	"INSERT INTO `clients` (`company_id`, `name`, `address`) VALUES (1, 'Client', 'Side St 2')",
+	"INSERT INTO `invoices` (`company_id`, `client_id`, `number`, `issue_date`, `fiscal_year`, `currency`, `subtotal`, `tax_rate`, `tax_amount`, `discount_amount`, `total`, `status`) " +
+		"VALUES (1, 1, 1, '2025-01-10', 2025, 'EUR', 100, 21, 21, 0, 121, 'Sent'), (1, 1, 1, '2025-01-11', 2025, 'EUR', 10, 21, 2.1, 0, 12.1, 'Draft')",
+	"INSERT INTO `invoice_items` (`invoice_id`, `description`, `quantity`, `unit_price`, `total`) VALUES (1, 'Work', 1.5, 66.67, 100)",
+	"INSERT INTO `company_defaults` (`company_id`, `default_currency`, `default_tax_rate`, `default_footer_text`) VALUES (1, 'EUR', 21, 'Thanks')",
+}
+
+func TestOpenBaselineDatabase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.db")
+	raw, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, stmt := range baselineSchema {
+		if _, err := raw.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+	if err := raw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Opening twice checks that the migrations leave a database they can open again
+	for i := 0; i < 2; i++ {
+		d, err := Open(path)
+		if err != nil {
+			t.Fatalf("open %d: %v", i+1, err)
+		}
+		if err := d.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	d, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+
+	var invoices []models.Invoice
+	if err := d.DB.Preload("Items").Order("id ASC").Find(&invoices).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(invoices) != 2 {
+		t.Fatalf("got %d invoices, want 2", len(invoices))
+	}
+	inv := invoices[0]
+	if inv.Total != models.DecimalFromFloat(121) || inv.TaxAmount != models.NewDecimal(21) {
+		t.Errorf("amounts = %s/%s, want 121/21", inv.Total, inv.TaxAmount)
+	}
+	if len(inv.Items) != 1 || inv.Items[0].Quantity != models.DecimalFromFloat(1.5) || inv.Items[0].UnitPrice != models.DecimalFromFloat(66.67) {
+		t.Errorf("items = %+v", inv.Items)
+	}
+	if inv.DocumentType != models.DocumentTypeInvoice || inv.AmountCredited != 0 {
+		t.Errorf("document type %q, amount credited %s", inv.DocumentType, inv.AmountCredited)
+	}
+	if inv.DisplayNumber != "1" || invoices[1].DisplayNumber != "1-2" {
+		t.Errorf("display numbers = %q, %q, want \"1\", \"1-2\"", inv.DisplayNumber, invoices[1].DisplayNumber)
+	}
+
+	var company models.Company
+	if err := d.DB.First(&company, 1).Error; err != nil {
+		t.Fatal(err)
+	}
+	if company.Address.Line1 != "Main St 1" {
+		t.Errorf("company address = %+v", company.Address)
+	}
+	var def models.CompanyDefaults
+	if err := d.DB.Where("company_id = ?", 1).First(&def).Error; err != nil {
+		t.Fatal(err)
+	}
+	if def.DefaultTaxRate != models.NewDecimal(21) || def.DefaultFooterText != "Thanks" {
+		t.Errorf("company defaults = %+v", def)
+	}
+}
@@ -0,0 +1,152 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fossinvoice/fossinvoice/internal/models"
+	"gorm.io/gorm"
+)
+
+// decimalColumns lists the fields stored as models.Decimal. Databases created before
+// the Decimal type existed keep them as REAL columns holding plain floats.
+var decimalColumns = []struct {
+	model  any
+	fields []string
+}{
+	{&models.Invoice{}, []string{"Subtotal", "TaxRate", "TaxAmount", "DiscountAmount", "Total"}},
+	{&models.InvoiceItem{}, []string{"Quantity", "UnitPrice", "Total"}},
+	{&models.CompanyDefaults{}, []string{"DefaultTaxRate"}},
+}
+
+// addressTables lists the tables whose single "address" text column became a models.PostalAddress.
+var addressTables = []any{&models.Company{}, &models.Client{}}
+
+// migrateAddresses moves the free-text address of companies and clients into the first line of the
+// structured address (address_line1), then drops the old column. Tables already migrated are skipped.
+func migrateAddresses(gdb *gorm.DB) error {
+	return gdb.Transaction(func(tx *gorm.DB) error {
+		m := tx.Migrator()
+		for _, model := range addressTables {
+			if !m.HasTable(model) || !m.HasColumn(model, "address") || m.HasColumn(model, "address_line1") {
+				continue
+			}
+			stmt := &gorm.Statement{DB: tx}
+			if err := stmt.Parse(model); err != nil {
+				return err
+			}
+			table := stmt.Schema.Table
+			if err := tx.Exec(fmt.Sprintf("ALTER TABLE `%s` ADD COLUMN `address_line1` text", table)).Error; err != nil {
+				return err
+			}
+			if err := tx.Exec(fmt.Sprintf("UPDATE `%s` SET `address_line1` = COALESCE(`address`, '')", table)).Error; err != nil {
+				return err
+			}
+			if err := tx.Exec(fmt.Sprintf("ALTER TABLE `%s` DROP COLUMN `address`", table)).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// migrateDecimalColumns converts legacy REAL columns into the scaled INTEGER
+// representation used by models.Decimal. Values are scaled to four fractional
+// digits and rounded, then the column type is changed, all in one transaction so a
+// failure never leaves a half-converted table behind. Columns that are already
+// INTEGER are left alone, which makes the migration safe to run on every open.
+func migrateDecimalColumns(gdb *gorm.DB) error {
+	return gdb.Transaction(func(tx *gorm.DB) error {
+		m := tx.Migrator()
+		for _, spec := range decimalColumns {
+			if !m.HasTable(spec.model) {
+				continue
+			}
+			columnTypes, err := m.ColumnTypes(spec.model)
+			if err != nil {
+				return err
+			}
+			types := make(map[string]string, len(columnTypes))
+			for _, ct := range columnTypes {
+				types[ct.Name()] = strings.ToLower(ct.DatabaseTypeName())
+			}
+
+			stmt := &gorm.Statement{DB: tx}
+			if err := stmt.Parse(spec.model); err != nil {
+				return err
+			}
+			for _, name := range spec.fields {
+				field := stmt.Schema.LookUpField(name)
+				if field == nil {
+					return fmt.Errorf("unknown decimal field %s", name)
+				}
+				t, ok := types[field.DBName]
+				if !ok || strings.Contains(t, "int") {
+					continue
+				}
+				if err := tx.Exec(
+					fmt.Sprintf("UPDATE `%s` SET `%s` = CAST(ROUND(`%s` * ?) AS INTEGER) WHERE `%s` IS NOT NULL",
+						stmt.Schema.Table, field.DBName, field.DBName, field.DBName),
+					int64(models.NewDecimal(1)), // scale factor
+				).Error; err != nil {
+					return err
+				}
+				if err := m.AlterColumn(spec.model, name); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// migrateAmountCredited adds Invoice.AmountCredited to databases created before it was stored and
+// fills it from the issued credit notes, so open balances and overdue filters account for them.
+// Databases created before credit notes existed get their columns first (AutoMigrate only runs
+// afterwards) and have nothing to fill.
+func migrateAmountCredited(gdb *gorm.DB) error {
+	m := gdb.Migrator()
+	if !m.HasTable(&models.Invoice{}) || m.HasColumn(&models.Invoice{}, "AmountCredited") {
+		return nil
+	}
+	return gdb.Transaction(func(tx *gorm.DB) error {
+		m := tx.Migrator()
+		for _, name := range []string{"DocumentType", "OriginalInvoiceID", "AmountCredited"} {
+			if !m.HasColumn(&models.Invoice{}, name) {
+				if err := m.AddColumn(&models.Invoice{}, name); err != nil {
+					return err
+				}
+			}
+		}
+		return tx.Exec("UPDATE `invoices` SET `amount_credited` = -(SELECT COALESCE(SUM(cn.`total`), 0) FROM `invoices` cn "+
+			"WHERE cn.`original_invoice_id` = `invoices`.`id` AND cn.`document_type` = ? AND cn.`status` NOT IN ? AND cn.`deleted_at` IS NULL)",
+			models.DocumentTypeCreditNote, []string{models.StatusDraft, models.StatusVoid}).Error
+	})
+}
+
+// migrateDisplayNumbers backfills Invoice.DisplayNumber for databases created before
+// numbering series existed, so the unique (company, document type, number) index can be
+// built by AutoMigrate. Legacy numbers become their plain text form; if a number was
+// already used twice (possible with the old MAX(number) allocation), the later rows get
+// their ID appended ("12-57") rather than failing to open the database.
+func migrateDisplayNumbers(gdb *gorm.DB) error {
+	m := gdb.Migrator()
+	if !m.HasTable(&models.Invoice{}) || m.HasColumn(&models.Invoice{}, "DisplayNumber") {
+		return nil
+	}
+	return gdb.Transaction(func(tx *gorm.DB) error {
+		m := tx.Migrator()
+		for _, name := range []string{"DocumentType", "DisplayNumber"} {
+			if !m.HasColumn(&models.Invoice{}, name) {
+				if err := m.AddColumn(&models.Invoice{}, name); err != nil {
+					return err
+				}
+			}
+		}
+		if err := tx.Exec("UPDATE `invoices` SET `display_number` = CAST(`number` AS TEXT)").Error; err != nil {
+			return err
+		}
+		return tx.Exec("UPDATE `invoices` SET `display_number` = `display_number` || '-' || `id` " +
+			"WHERE `id` NOT IN (SELECT MIN(`id`) FROM `invoices` GROUP BY `company_id`, `document_type`, `display_number`)").Error
+	})
+}
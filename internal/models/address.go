@@ -0,0 +1,92 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// PostalAddress is a structured postal address, embedded into the Company and Client tables
+// with an "address_" column prefix.
+type PostalAddress struct {
+	Line1      string // street and number; holds the whole text of addresses entered before structured fields existed
+	Line2      string // optional: floor, suite, building...
+	PostalCode string
+	City       string
+	Region     string // state, province or county
+	Country    string // ISO 3166-1 alpha-2 code, e.g. "ES"
+}
+
+// UnmarshalJSON accepts a structured address object, or a plain string (the former
+// single-field address) which is stored in Line1.
+func (a *PostalAddress) UnmarshalJSON(b []byte) error {
+	var text string
+	if err := json.Unmarshal(b, &text); err == nil {
+		*a = PostalAddress{Line1: text}
+		return nil
+	}
+	type plain PostalAddress
+	return json.Unmarshal(b, (*plain)(a))
+}
+
+// IsZero reports whether no field of the address is set.
+func (a PostalAddress) IsZero() bool { return a == PostalAddress{} }
+
+// Lines returns the address as printed inside its country, without the country line: the street
+// lines followed by the locality written the way the country's postal service expects, e.g.
+// "28013 Madrid" in Spain, "Springfield, IL 62701" in the United States, or city and postcode on
+// separate lines in the United Kingdom.
+func (a PostalAddress) Lines() []string {
+	var lines []string
+	for _, l := range strings.Split(a.Line1, "\n") {
+		lines = appendNonEmpty(lines, l)
+	}
+	lines = appendNonEmpty(lines, a.Line2)
+
+	pc, city, region := strings.TrimSpace(a.PostalCode), strings.TrimSpace(a.City), strings.TrimSpace(a.Region)
+	switch strings.ToUpper(a.Country) {
+	case "US", "CA":
+		// City, ST 12345
+		locality := city
+		if region != "" {
+			locality = joinNonEmpty(", ", locality, region)
+		}
+		lines = appendNonEmpty(lines, joinNonEmpty(" ", locality, pc))
+	case "AU":
+		lines = appendNonEmpty(lines, joinNonEmpty(" ", strings.ToUpper(city), region, pc))
+	case "GB", "IE":
+		lines = appendNonEmpty(lines, strings.ToUpper(city))
+		lines = appendNonEmpty(lines, region)
+		lines = appendNonEmpty(lines, strings.ToUpper(pc))
+	case "IT":
+		// 00184 Roma RM
+		lines = appendNonEmpty(lines, joinNonEmpty(" ", pc, city, strings.ToUpper(region)))
+	case "ES":
+		// 28013 Madrid, province on its own line when it differs from the city
+		lines = appendNonEmpty(lines, joinNonEmpty(" ", pc, city))
+		if !strings.EqualFold(region, city) {
+			lines = appendNonEmpty(lines, region)
+		}
+	default:
+		// Most of Europe and Latin America: postal code before the city
+		lines = appendNonEmpty(lines, joinNonEmpty(" ", pc, city))
+		lines = appendNonEmpty(lines, region)
+	}
+	return lines
+}
+
+func appendNonEmpty(lines []string, s string) []string {
+	if s = strings.TrimSpace(s); s != "" {
+		return append(lines, s)
+	}
+	return lines
+}
+
+func joinNonEmpty(sep string, parts ...string) string {
+	var out []string
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return strings.Join(out, sep)
+}
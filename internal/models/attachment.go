@@ -0,0 +1,16 @@
+package models
+
+import "gorm.io/gorm"
+
+// Attachment is a file kept with an invoice, e.g. a signed order, a timesheet or a delivery receipt.
+// The content is stored in the database next to its SHA-256 digest, which is checked when the file is
+// extracted again.
+type Attachment struct {
+	gorm.Model
+	InvoiceID uint   `gorm:"index"`
+	FileName  string // base name of the original file
+	MimeType  string
+	Size      int64
+	SHA256    string `gorm:"column:sha256"` // hex-encoded digest of Data
+	Data      []byte `json:"-"`             // only loaded to extract or embed the file
+}
@@ -0,0 +1,121 @@
+package models
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrInvalidIBAN is returned when an IBAN has a bad format, length or check digits.
+	ErrInvalidIBAN = errors.New("invalid IBAN")
+	// ErrInvalidBIC is returned when a BIC (SWIFT code) does not have 8 or 11 valid characters.
+	ErrInvalidBIC = errors.New("invalid BIC")
+)
+
+// BankAccount is an account of a company that clients pay into, printed in the "Payment details"
+// block of invoices. Accounts outside IBAN countries leave IBAN empty and use Details instead.
+type BankAccount struct {
+	gorm.Model
+	CompanyID     uint   `gorm:"index"`
+	Name          string // label shown when picking the account, e.g. "Main EUR account"
+	AccountHolder string // printed as beneficiary; empty uses the company name
+	BankName      string
+	IBAN          string // stored normalized: upper case without spaces
+	BIC           string // 8 or 11 characters, upper case
+	Details       string // other payment instructions, e.g. account and routing number
+}
+
+// IBAN lengths per country (ISO 13616 registry). Countries not listed only get the generic checks.
+var ibanLengths = map[string]int{
+	"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16, "BG": 22, "BH": 22, "BR": 29,
+	"CH": 21, "CR": 22, "CY": 28, "CZ": 24, "DE": 22, "DK": 18, "DO": 28, "EE": 20, "EG": 29, "ES": 24,
+	"FI": 18, "FO": 18, "FR": 27, "GB": 22, "GE": 22, "GI": 23, "GL": 18, "GR": 27, "GT": 28, "HR": 21,
+	"HU": 28, "IE": 22, "IL": 23, "IS": 26, "IT": 27, "JO": 30, "KW": 30, "KZ": 20, "LB": 28, "LI": 21,
+	"LT": 20, "LU": 20, "LV": 21, "MC": 27, "MD": 24, "ME": 22, "MK": 19, "MR": 27, "MT": 31, "MU": 30,
+	"NL": 18, "NO": 15, "PK": 24, "PL": 28, "PS": 29, "PT": 25, "QA": 29, "RO": 24, "RS": 22, "SA": 24,
+	"SE": 24, "SI": 19, "SK": 24, "SM": 27, "TN": 24, "TR": 26, "UA": 29, "VA": 22, "VG": 24, "XK": 20,
+}
+
+var (
+	ibanPattern = regexp.MustCompile(`^[A-Z]{2}[0-9]{2}[A-Z0-9]{11,30}$`)
+	bicPattern  = regexp.MustCompile(`^[A-Z]{4}[A-Z]{2}[A-Z0-9]{2}([A-Z0-9]{3})?$`)
+)
+
+// NormalizeIBAN removes spaces and dashes and upper-cases an IBAN.
+func NormalizeIBAN(iban string) string {
+	return strings.ToUpper(strings.NewReplacer(" ", "", "-", "", "\t", "").Replace(strings.TrimSpace(iban)))
+}
+
+// ValidateIBAN checks the format, the country length and the ISO 7064 mod-97 check digits of an IBAN.
+func ValidateIBAN(iban string) error {
+	iban = NormalizeIBAN(iban)
+	if !ibanPattern.MatchString(iban) {
+		return ErrInvalidIBAN
+	}
+	if n, ok := ibanLengths[iban[:2]]; ok && len(iban) != n {
+		return ErrInvalidIBAN
+	}
+	// Move the country code and check digits to the end and read letters as 10..35
+	rearranged := iban[4:] + iban[:4]
+	rem := 0
+	for _, c := range rearranged {
+		if c >= 'A' {
+			v := int(c-'A') + 10
+			rem = (rem*100 + v) % 97
+		} else {
+			rem = (rem*10 + int(c-'0')) % 97
+		}
+	}
+	if rem != 1 {
+		return ErrInvalidIBAN
+	}
+	return nil
+}
+
+// ValidateBIC checks that a BIC (SWIFT code) is 8 or 11 characters: bank, country, location and optional branch.
+func ValidateBIC(bic string) error {
+	if !bicPattern.MatchString(strings.ToUpper(strings.TrimSpace(bic))) {
+		return ErrInvalidBIC
+	}
+	return nil
+}
+
+// FormatIBAN returns an IBAN in groups of four characters, as printed on paper.
+func FormatIBAN(iban string) string {
+	iban = NormalizeIBAN(iban)
+	var b strings.Builder
+	for i, c := range iban {
+		if i > 0 && i%4 == 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// Normalize cleans up the IBAN and BIC for storage.
+func (a *BankAccount) Normalize() {
+	a.IBAN = NormalizeIBAN(a.IBAN)
+	a.BIC = strings.ToUpper(strings.TrimSpace(a.BIC))
+}
+
+// Validate checks the IBAN and BIC when given; an account needs an IBAN or other payment details.
+func (a *BankAccount) Validate() error {
+	if a.IBAN == "" && strings.TrimSpace(a.Details) == "" {
+		return gorm.ErrInvalidData
+	}
+	if a.IBAN != "" {
+		if err := ValidateIBAN(a.IBAN); err != nil {
+			return err
+		}
+	}
+	if a.BIC != "" {
+		if err := ValidateBIC(a.BIC); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package models
+
+import "gorm.io/gorm"
+
+// CatalogItem is a product or service a company sells, used to prefill invoice lines.
+// Invoice items copy its values when added, so later catalog changes never alter issued documents.
+type CatalogItem struct {
+	gorm.Model
+	CompanyID      uint     `gorm:"index"`
+	Code           string   // optional SKU / internal code, unique per company when set
+	Name           string   // short name, e.g. "Consulting hour"
+	Description    string   // text copied to the invoice line; Name is used when empty
+	Unit           string   // unit of sale (UN/ECE Rec 20 code, see StandardUnits), e.g. UnitHour
+	DefaultPrice   Decimal  // unit price copied to new invoice lines
+	DefaultTaxRate *Decimal // percentage; nil uses the invoice TaxRate
+	Active         bool     `gorm:"default:true"` // inactive entries are hidden from search but kept for history
+}
+
+// NewInvoiceItem returns an invoice line for the given quantity holding a snapshot of the catalog values.
+func (c *CatalogItem) NewInvoiceItem(quantity Decimal) InvoiceItem {
+	desc := c.Description
+	if desc == "" {
+		desc = c.Name
+	}
+	catalogID := c.ID
+	it := InvoiceItem{
+		CatalogItemID: &catalogID,
+		Code:          c.Code,
+		Description:   desc,
+		Quantity:      quantity,
+		Unit:          c.Unit,
+		UnitPrice:     c.DefaultPrice,
+	}
+	if c.DefaultTaxRate != nil {
+		rate := *c.DefaultTaxRate
+		it.TaxRate = &rate
+	}
+	return it
+}
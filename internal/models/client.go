@@ -8,11 +8,15 @@ type Client struct {
 	CompanyID uint // FK to Company
 
 	Name    string
-	Address string
+	Address PostalAddress `gorm:"embedded;embeddedPrefix:address_"`
 	TaxID   string
 
 	// Inline contact fields for simplicity
 	Contact ContactInfo `gorm:"embedded"`
 
+	// Additional contacts and addresses by role (billing, accounts payable, shipping, ...)
+	Contacts  []ClientContact
+	Addresses []ClientAddress
+
 	Invoices []Invoice
 }
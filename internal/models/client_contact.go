@@ -0,0 +1,74 @@
+package models
+
+import (
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Roles for ClientContact.Role.
+const (
+	ContactRoleBilling         = "Billing"         // person invoices are addressed to
+	ContactRoleAccountsPayable = "AccountsPayable" // mailbox that processes incoming invoices
+	ContactRoleOther           = "Other"
+)
+
+// Roles for ClientAddress.Role.
+const (
+	AddressRoleBilling  = "Billing"
+	AddressRoleShipping = "Shipping"
+	AddressRoleOther    = "Other"
+)
+
+// ErrInvalidRole is returned when a client contact or address has an unknown role.
+var ErrInvalidRole = errors.New("unknown contact or address role")
+
+// ClientContact is an additional contact person or mailbox of a client. The contact embedded in
+// Client remains the general one; these records allow one per purpose.
+type ClientContact struct {
+	gorm.Model
+	ClientID  uint        `gorm:"index"`
+	Role      string      // one of the ContactRole* constants
+	Name      string      // person or department, e.g. "Accounts payable"
+	Contact   ContactInfo `gorm:"embedded"`
+	IsDefault bool        // used for new invoices when none is picked (one per client and role)
+}
+
+// ClientAddress is an additional postal address of a client, such as a separate billing or shipping address.
+type ClientAddress struct {
+	gorm.Model
+	ClientID  uint          `gorm:"index"`
+	Role      string        // one of the AddressRole* constants
+	Label     string        // optional name shown when picking the address, e.g. "Head office"
+	Address   PostalAddress `gorm:"embedded;embeddedPrefix:address_"`
+	IsDefault bool          // used for new invoices when none is picked (one per client and role)
+}
+
+// Validate checks the role and that the contact has a name or at least one way to reach it.
+func (c *ClientContact) Validate() error {
+	switch c.Role {
+	case ContactRoleBilling, ContactRoleAccountsPayable, ContactRoleOther:
+	default:
+		return ErrInvalidRole
+	}
+	if strings.TrimSpace(c.Name) == "" && isBlank(c.Contact.Email) && isBlank(c.Contact.Phone) {
+		return gorm.ErrInvalidData
+	}
+	return nil
+}
+
+// Validate checks the role and that the address is not empty.
+func (a *ClientAddress) Validate() error {
+	switch a.Role {
+	case AddressRoleBilling, AddressRoleShipping, AddressRoleOther:
+	default:
+		return ErrInvalidRole
+	}
+	if a.Address.IsZero() {
+		return gorm.ErrInvalidData
+	}
+	return nil
+}
+
+func isBlank(s *string) bool { return s == nil || strings.TrimSpace(*s) == "" }
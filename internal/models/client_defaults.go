@@ -0,0 +1,73 @@
+package models
+
+import "gorm.io/gorm"
+
+// ClientDefaults optionally overrides the company defaults for one client (one-to-one), e.g. a
+// foreign client invoiced in another currency, at a 0% reverse-charge rate and in its own language.
+// Nil or empty fields inherit the company value.
+type ClientDefaults struct {
+	gorm.Model
+	ClientID        uint         `gorm:"uniqueIndex"`
+	Currency        string       // ISO 4217 code; empty inherits
+	TaxRate         *Decimal     // percentage; nil inherits, 0 for reverse charge / exempt
+	WithholdingRate *Decimal     // percentage; nil inherits
+	PaymentTerms    PaymentTerms `gorm:"embedded;embeddedPrefix:payment_terms_"` // zero inherits
+	Language        string       // document language (BCP47, e.g. "es"); empty uses the application language
+	FooterText      *string      // nil inherits, "" prints no footer
+}
+
+// InvoiceDefaults are the values a new invoice for a client starts with: the company defaults
+// with the client defaults merged on top.
+type InvoiceDefaults struct {
+	Currency        string
+	TaxRate         Decimal
+	WithholdingRate Decimal
+	PaymentTerms    PaymentTerms // zero when no terms are set
+	BankAccountID   *uint        // company bank account; nil for none
+	Language        string
+	FooterText      string
+}
+
+// MergeDefaults returns the invoice defaults for a client. Either argument may be nil.
+func MergeDefaults(company *CompanyDefaults, client *ClientDefaults) InvoiceDefaults {
+	var out InvoiceDefaults
+	if company != nil {
+		out.Currency = company.DefaultCurrency
+		out.PaymentTerms = company.DefaultPaymentTerms
+		out.BankAccountID = company.DefaultBankAccountID
+		out.TaxRate = company.DefaultTaxRate
+		out.WithholdingRate = company.DefaultWithholdingRate
+		out.FooterText = company.DefaultFooterText
+	}
+	if client == nil {
+		return out
+	}
+	if client.Currency != "" {
+		out.Currency = client.Currency
+	}
+	if client.TaxRate != nil {
+		out.TaxRate = *client.TaxRate
+	}
+	if client.WithholdingRate != nil {
+		out.WithholdingRate = *client.WithholdingRate
+	}
+	if !client.PaymentTerms.IsZero() {
+		out.PaymentTerms = client.PaymentTerms
+	}
+	out.Language = client.Language
+	if client.FooterText != nil {
+		out.FooterText = *client.FooterText
+	}
+	return out
+}
+
+// Validate checks the rates and payment terms of the client defaults.
+func (d *ClientDefaults) Validate() error {
+	if d.TaxRate != nil && !validRate(*d.TaxRate) {
+		return ErrInvalidTaxRate
+	}
+	if d.WithholdingRate != nil && !validRate(*d.WithholdingRate) {
+		return ErrInvalidWithholdingRate
+	}
+	return d.PaymentTerms.Validate()
+}
@@ -6,7 +6,7 @@ import "gorm.io/gorm"
 type Company struct {
 	gorm.Model
 	Name    string
-	Address string
+	Address PostalAddress `gorm:"embedded;embeddedPrefix:address_"`
 	TaxID   string
 	IconB64 string
 
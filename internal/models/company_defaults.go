@@ -5,9 +5,12 @@ import "gorm.io/gorm"
 // CompanyDefaults stores default configuration for a company (one-to-one).
 type CompanyDefaults struct {
 	gorm.Model
-	CompanyID         uint `gorm:"uniqueIndex"`
-	Company           Company
-	DefaultCurrency   string  // ISO 4217 code e.g. "USD", "EUR"
-	DefaultTaxRate    float64 // percentage, e.g., 21.0
-	DefaultFooterText string
+	CompanyID              uint `gorm:"uniqueIndex"`
+	Company                Company
+	DefaultCurrency        string  // ISO 4217 code e.g. "USD", "EUR"
+	DefaultTaxRate         Decimal // percentage, e.g., 21.0
+	DefaultWithholdingRate Decimal // IRPF / ritenuta d'acconto percentage, 0 if not applicable
+	DefaultFooterText      string
+	DefaultPaymentTerms    PaymentTerms `gorm:"embedded;embeddedPrefix:default_payment_terms_"` // zero for none
+	DefaultBankAccountID   *uint        // bank account printed on new invoices; nil for none
 }
@@ -0,0 +1,267 @@
+package models
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// DecimalPlaces is the number of fractional digits kept by Decimal.
+const DecimalPlaces = 4
+
+// decimalFactor is 10^DecimalPlaces.
+const decimalFactor = 10000
+
+// ErrInvalidDecimal is returned when a value cannot be parsed as a Decimal.
+var ErrInvalidDecimal = errors.New("invalid decimal value")
+
+// Decimal is an exact fixed-point number with four fractional digits, stored as a
+// scaled integer (e.g. 12.5 is Decimal(125000)). It is used for amounts, quantities
+// and rates so that sums never drift. In the database it is an INTEGER column, and
+// in JSON it is a plain number so the frontend keeps working with numbers.
+type Decimal int64
+
+// NewDecimal returns the Decimal for a whole number of units.
+func NewDecimal(units int64) Decimal { return Decimal(units * decimalFactor) }
+
+// DecimalFromFloat converts a float, rounding half away from zero to four places.
+func DecimalFromFloat(f float64) Decimal { return Decimal(math.Round(f * decimalFactor)) }
+
+// ParseDecimal parses a plain decimal string such as "-12.345". Extra fractional
+// digits beyond four places are rounded half away from zero.
+func ParseDecimal(s string) (Decimal, error) {
+	v, err := parseScaled(s, DecimalPlaces)
+	return Decimal(v), err
+}
+
+// parseScaled parses a plain decimal string into an integer scaled by 10^places, rounding
+// extra fractional digits half away from zero.
+func parseScaled(s string, places int) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, ErrInvalidDecimal
+	}
+	factor := int64(math.Pow10(places))
+	// Fall back to float parsing for exponent notation (e.g. 1e-7 sent by JS).
+	if strings.ContainsAny(s, "eE") {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil || math.Abs(f) >= float64(math.MaxInt64/factor) {
+			return 0, ErrInvalidDecimal
+		}
+		return int64(math.Round(f * float64(factor))), nil
+	}
+	neg := false
+	switch s[0] {
+	case '-':
+		neg = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	if intPart == "" && fracPart == "" {
+		return 0, ErrInvalidDecimal
+	}
+	if intPart == "" {
+		intPart = "0"
+	}
+	roundUp := false
+	if len(fracPart) > places {
+		if fracPart[places] < '0' || fracPart[places] > '9' {
+			return 0, ErrInvalidDecimal
+		}
+		roundUp = fracPart[places] >= '5'
+		for _, c := range fracPart[places:] {
+			if c < '0' || c > '9' {
+				return 0, ErrInvalidDecimal
+			}
+		}
+		fracPart = fracPart[:places]
+	}
+	fracPart += strings.Repeat("0", places-len(fracPart))
+	ip, err := strconv.ParseUint(intPart, 10, 63)
+	if err != nil {
+		return 0, ErrInvalidDecimal
+	}
+	var fp uint64
+	if places > 0 {
+		if fp, err = strconv.ParseUint(fracPart, 10, 63); err != nil {
+			return 0, ErrInvalidDecimal
+		}
+	}
+	if ip > uint64(math.MaxInt64/factor-1) {
+		return 0, ErrInvalidDecimal
+	}
+	v := int64(ip)*factor + int64(fp)
+	if roundUp {
+		v++
+	}
+	if neg {
+		v = -v
+	}
+	return v, nil
+}
+
+// Float64 returns the closest float64 (for display or interop only, never for arithmetic).
+func (d Decimal) Float64() float64 { return float64(d) / decimalFactor }
+
+// Add returns d + o.
+func (d Decimal) Add(o Decimal) Decimal { return d + o }
+
+// Sub returns d - o.
+func (d Decimal) Sub(o Decimal) Decimal { return d - o }
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal { return -d }
+
+// Abs returns the absolute value of d.
+func (d Decimal) Abs() Decimal {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// IsZero reports whether d is zero.
+func (d Decimal) IsZero() bool { return d == 0 }
+
+// Mul returns d * o rounded half away from zero to four places.
+func (d Decimal) Mul(o Decimal) Decimal {
+	p := new(big.Int).Mul(big.NewInt(int64(d)), big.NewInt(int64(o)))
+	return Decimal(divRound(p, big.NewInt(decimalFactor)))
+}
+
+// Div returns d / o rounded half away from zero to four places. Division by zero yields zero.
+func (d Decimal) Div(o Decimal) Decimal {
+	if o == 0 {
+		return 0
+	}
+	n := new(big.Int).Mul(big.NewInt(int64(d)), big.NewInt(decimalFactor))
+	return Decimal(divRound(n, big.NewInt(int64(o))))
+}
+
+// Percent returns rate percent of d (d * rate / 100), rounded to four places.
+func (d Decimal) Percent(rate Decimal) Decimal {
+	p := new(big.Int).Mul(big.NewInt(int64(d)), big.NewInt(int64(rate)))
+	return Decimal(divRound(p, big.NewInt(decimalFactor*100)))
+}
+
+// Round rounds d half away from zero to the given number of fractional digits (0..4).
+func (d Decimal) Round(places int) Decimal {
+	if places >= DecimalPlaces {
+		return d
+	}
+	if places < 0 {
+		places = 0
+	}
+	unit := int64(math.Pow10(DecimalPlaces - places))
+	q := divRound(big.NewInt(int64(d)), big.NewInt(unit))
+	return Decimal(q * unit)
+}
+
+// RoundCurrency rounds d to the minor unit of the given ISO 4217 currency.
+func (d Decimal) RoundCurrency(currency string) Decimal {
+	return d.Round(CurrencyDecimals(currency))
+}
+
+// String returns the shortest plain representation, e.g. "12.5" or "-3".
+func (d Decimal) String() string {
+	s := d.StringFixed(DecimalPlaces)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// StringFixed formats d with exactly the given number of fractional digits (0..4),
+// rounding half away from zero.
+func (d Decimal) StringFixed(places int) string {
+	if places > DecimalPlaces {
+		places = DecimalPlaces
+	}
+	if places < 0 {
+		places = 0
+	}
+	r := int64(d.Round(places))
+	neg := r < 0
+	u := uint64(r)
+	if neg {
+		u = uint64(-r)
+	}
+	ip := u / decimalFactor
+	fp := u % decimalFactor
+	var b strings.Builder
+	if neg {
+		b.WriteByte('-')
+	}
+	b.WriteString(strconv.FormatUint(ip, 10))
+	if places > 0 {
+		frac := strconv.FormatUint(fp+decimalFactor, 10)[1:] // zero-padded to four digits
+		b.WriteByte('.')
+		b.WriteString(frac[:places])
+	}
+	return b.String()
+}
+
+// MarshalJSON encodes d as a JSON number.
+func (d Decimal) MarshalJSON() ([]byte, error) { return []byte(d.String()), nil }
+
+// UnmarshalJSON accepts a JSON number, a numeric string or null.
+func (d *Decimal) UnmarshalJSON(b []byte) error {
+	s := strings.TrimSpace(string(b))
+	if s == "null" {
+		return nil
+	}
+	s = strings.Trim(s, `"`)
+	if s == "" {
+		*d = 0
+		return nil
+	}
+	v, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// divRound divides n by m rounding half away from zero.
+func divRound(n, m *big.Int) int64 {
+	q, r := new(big.Int).QuoRem(n, m, new(big.Int))
+	if r.Sign() != 0 {
+		twice := new(big.Int).Abs(r)
+		twice.Lsh(twice, 1)
+		if twice.Cmp(new(big.Int).Abs(m)) >= 0 {
+			if (n.Sign() < 0) != (m.Sign() < 0) {
+				q.Sub(q, big.NewInt(1))
+			} else {
+				q.Add(q, big.NewInt(1))
+			}
+		}
+	}
+	return q.Int64()
+}
+
+// zeroDecimalCurrencies and threeDecimalCurrencies list ISO 4217 codes whose minor
+// unit differs from the usual two digits.
+var (
+	zeroDecimalCurrencies = map[string]struct{}{
+		"BIF": {}, "CLP": {}, "DJF": {}, "GNF": {}, "ISK": {}, "JPY": {}, "KMF": {}, "KRW": {},
+		"PYG": {}, "RWF": {}, "UGX": {}, "UYI": {}, "VND": {}, "VUV": {}, "XAF": {}, "XOF": {}, "XPF": {},
+	}
+	threeDecimalCurrencies = map[string]struct{}{
+		"BHD": {}, "IQD": {}, "JOD": {}, "KWD": {}, "LYD": {}, "OMR": {}, "TND": {},
+	}
+)
+
+// CurrencyDecimals returns the number of minor-unit digits for an ISO 4217 code (default 2).
+func CurrencyDecimals(currency string) int {
+	c := strings.ToUpper(strings.TrimSpace(currency))
+	if _, ok := zeroDecimalCurrencies[c]; ok {
+		return 0
+	}
+	if _, ok := threeDecimalCurrencies[c]; ok {
+		return 3
+	}
+	return 2
+}
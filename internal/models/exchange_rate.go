@@ -0,0 +1,41 @@
+package models
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// Sources of ExchangeRate.Source.
+const (
+	RateSourceManual = "Manual"
+	RateSourceECB    = "ECB"
+)
+
+// ErrInvalidExchangeRate is returned for a rate that is not positive or lacks its date or currencies.
+var ErrInvalidExchangeRate = errors.New("invalid exchange rate")
+
+// ExchangeRate is the price of one unit of BaseCurrency in Currency on a day, quoted like the ECB
+// reference rates: BaseCurrency EUR, Currency USD, Rate 1.0812 means 1 EUR = 1.0812 USD.
+// There is at most one rate per day and currency pair; importing again replaces it.
+type ExchangeRate struct {
+	gorm.Model
+	Date         string `gorm:"uniqueIndex:idx_exchange_rate_day,priority:1"` // ISO date (YYYY-MM-DD)
+	BaseCurrency string `gorm:"uniqueIndex:idx_exchange_rate_day,priority:2"` // ISO 4217 code, e.g. "EUR"
+	Currency     string `gorm:"uniqueIndex:idx_exchange_rate_day,priority:3"` // ISO 4217 code, e.g. "USD"
+	Rate         Rate   // units of Currency per unit of BaseCurrency
+	Source       string // RateSourceManual or RateSourceECB
+}
+
+// ToBase converts an amount in the invoice currency to the company base currency using the
+// invoice's exchange rate snapshot, rounded to the base currency. ok is false when the invoice
+// has no rate (none was known when it was issued).
+func (inv *Invoice) ToBase(amount Decimal) (converted Decimal, ok bool) {
+	if inv.BaseCurrency == "" || inv.Currency == inv.BaseCurrency {
+		return amount, true
+	}
+	if inv.ExchangeRate <= 0 {
+		return 0, false
+	}
+	return amount.Convert(NewRate(1), inv.ExchangeRate).RoundCurrency(inv.BaseCurrency), true
+}
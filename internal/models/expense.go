@@ -0,0 +1,55 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Expense is a cost of a company, e.g. a supplier invoice or a till receipt. Amounts are in Currency;
+// reports convert them to the company's base currency at the rate of Date.
+type Expense struct {
+	gorm.Model
+	CompanyID   uint   `gorm:"index"`
+	Date        string // ISO date of the supplier document
+	FiscalYear  int    `gorm:"index"` // defaults to the year of Date
+	Supplier    string
+	Category    string // free text, e.g. "Travel" or "Software"
+	Description string
+	Net         Decimal // amount before tax
+	Tax         Decimal // tax paid, deducted from the tax collected in reports
+	Total       Decimal // Net + Tax
+	Currency    string  // ISO 4217 code
+	// Receipt attachment (scan or PDF of the supplier document). The content is only loaded by
+	// ExportExpenseReceipt, never sent along with the expense.
+	ReceiptName     string
+	ReceiptMimeType string
+	Receipt         []byte `json:"-"`
+}
+
+// Normalize trims the text fields, upper-cases the currency, derives the fiscal year from the date
+// when unset and computes Total.
+func (e *Expense) Normalize() {
+	e.Date = strings.TrimSpace(e.Date)
+	e.Supplier = strings.TrimSpace(e.Supplier)
+	e.Category = strings.TrimSpace(e.Category)
+	e.Currency = strings.ToUpper(strings.TrimSpace(e.Currency))
+	if e.FiscalYear == 0 {
+		if d, err := time.Parse(time.DateOnly, e.Date); err == nil {
+			e.FiscalYear = d.Year()
+		}
+	}
+	e.Total = e.Net.Add(e.Tax)
+}
+
+// Validate checks that the expense has a valid date and a currency code.
+func (e *Expense) Validate() error {
+	if _, err := time.Parse(time.DateOnly, e.Date); err != nil {
+		return gorm.ErrInvalidData
+	}
+	if len(e.Currency) != 3 {
+		return gorm.ErrInvalidData
+	}
+	return nil
+}
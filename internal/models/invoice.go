@@ -2,46 +2,127 @@ package models
 
 import "gorm.io/gorm"
 
+// Invoice statuses stored in Invoice.Status.
+const (
+	StatusDraft         = "Draft"
+	StatusPending       = "Pending"
+	StatusSent          = "Sent"
+	StatusPartiallyPaid = "PartiallyPaid"
+	StatusPaid          = "Paid"
+	StatusVoid          = "Void"
+	StatusAccepted      = "Accepted" // quotes only
+	StatusRejected      = "Rejected" // quotes only
+)
+
+// Document types stored in Invoice.DocumentType.
+const (
+	DocumentTypeInvoice    = "Invoice"
+	DocumentTypeCreditNote = "CreditNote"
+	DocumentTypeQuote      = "Quote"
+)
+
 type Invoice struct {
 	gorm.Model
 
 	// Ownership / FKs
-	CompanyID uint
+	CompanyID uint `gorm:"uniqueIndex:idx_invoices_number,priority:1"`
 	ClientID  uint
 	Company   Company
 	Client    Client
 
+	// Client contact and address the document is addressed to; see ClientContact and ClientAddress.
+	// When nil, the PDF prints the contact and address stored on the client itself.
+	BillingContactID *uint
+	BillingAddressID *uint
+
+	// Company bank account printed in the payment details; the company default when not picked
+	BankAccountID *uint
+
+	// Document kind; credit notes reference the invoice they correct and carry negative amounts
+	DocumentType      string `gorm:"default:Invoice;uniqueIndex:idx_invoices_number,priority:2"` // one of the DocumentType* constants
+	OriginalInvoiceID *uint  // credited invoice, set only for credit notes
+	QuoteID           *uint  // quote an invoice was converted from
+
 	// Identification & dates
-	Number    int    // human-readable invoice number (numeric)
-	IssueDate string // ISO date (YYYY-MM-DD)
-	DueDate   string // ISO date (YYYY-MM-DD)
+	SeriesID      *uint        // numbering series the number was allocated from; nil when numbered manually
+	Number        int          // sequence number within the series (or the manual number)
+	DisplayNumber string       `gorm:"uniqueIndex:idx_invoices_number,priority:3,where:display_number <> ''"` // formatted number printed on the document, e.g. "INV-2025-0001"; empty for drafts not numbered yet
+	IssueDate     string       // ISO date (YYYY-MM-DD)
+	DueDate       string       // ISO date (YYYY-MM-DD)
+	ValidUntil    string       // quotes only: ISO date until which the quote can be accepted
+	PaymentTerms  PaymentTerms `gorm:"embedded;embeddedPrefix:payment_terms_"` // DueDate is computed from them when set
 	// Fiscal categorization
 	FiscalYear int // e.g., 2025
 
 	// Currency & amounts
 	Currency       string  // ISO 4217 code, e.g. "USD", "EUR"
-	Subtotal       float64 // sum of item totals before tax and discounts
-	TaxRate        float64 // percentage, e.g. 21.0 for 21%
-	TaxAmount      float64 // computed tax amount over the taxable base
-	DiscountAmount float64 // optional absolute discount applied at invoice level
-	Total          float64 // grand total after tax and discounts
+	Subtotal       Decimal // sum of item totals (after line discounts) before invoice discount and tax
+	TaxRate        Decimal // default percentage for items without their own rate, e.g. 21.0 for 21%
+	TaxAmount      Decimal // sum of the tax amounts in TaxLines
+	DiscountRate   Decimal // optional invoice-level percentage discount; when set, DiscountAmount is derived from it
+	DiscountAmount Decimal // invoice-level absolute discount, applied before tax
+	// Withholding (e.g. Spanish IRPF, Italian ritenuta d'acconto) deducted from the taxable base
+	WithholdingRate   Decimal // percentage, e.g. 15.0 for 15%
+	WithholdingAmount Decimal // computed amount withheld by the client
+	Total             Decimal // amount payable after tax, discounts and withholding
+	AmountPaid        Decimal // sum of non-voided payments, maintained by the payments ledger
+	AmountCredited    Decimal // sum of the issued credit notes against the invoice, as a positive amount
+
+	// Exchange rate snapshot taken while the invoice is a Draft, frozen once it is issued
+	BaseCurrency       string // company default currency at that time
+	ExchangeRate       Rate   // units of Currency per unit of BaseCurrency; 1 for the same currency, 0 if unknown
+	ExchangeRateManual bool   // ExchangeRate was entered by hand and is not refreshed from the stored rates
 
 	// Status & presentation
-	Status string  // e.g. "Draft", "Sent", "Paid", "Overdue"
-	Notes  *string // optional footer/notes to show on the PDF
+	Status   string  // one of the Status* constants; Paid/PartiallyPaid are set automatically from payments
+	Notes    *string // optional footer/notes to show on the PDF
+	Language string  // document language (BCP47) taken from the client defaults; empty uses the export language
+
+	// Overdue state, derived from DueDate when invoices are loaded (not stored)
+	IsOverdue   bool `gorm:"-"`
+	DaysOverdue int  `gorm:"-"` // days past DueDate, 0 when not overdue
 
 	// Footer text printed at the bottom of the invoice PDF
 	FooterText string
 
 	// Lines
 	Items []InvoiceItem
+
+	// Per-rate tax breakdown, derived from the lines by ComputeTotals
+	TaxLines []InvoiceTaxLine
+
+	// Payments received against the invoice
+	Payments []Payment
 }
 
+// IsCreditNote reports whether the document is a credit note.
+func (inv *Invoice) IsCreditNote() bool { return inv.DocumentType == DocumentTypeCreditNote }
+
+// IsQuote reports whether the document is a quote (estimate).
+func (inv *Invoice) IsQuote() bool { return inv.DocumentType == DocumentTypeQuote }
+
 type InvoiceItem struct {
+	gorm.Model
+	InvoiceID uint
+	// Catalog entry the line was created from; the fields below are a snapshot taken at that time
+	CatalogItemID *uint
+	Code          string // catalog code / SKU
+	Description   string
+	Quantity      Decimal // supports fractional quantities (e.g., hours)
+	Unit          string  // unit of measure (UN/ECE Rec 20 code, see StandardUnits); empty for none
+	UnitPrice     Decimal
+	TaxRate       *Decimal // percentage; nil uses the invoice TaxRate
+	// Line discount: a percentage, or an absolute amount when DiscountRate is zero
+	DiscountRate   Decimal // percentage off Quantity * UnitPrice
+	DiscountAmount Decimal // absolute discount; derived from DiscountRate when that is set
+	Total          Decimal // Quantity * UnitPrice - DiscountAmount
+}
+
+// InvoiceTaxLine is one row of the tax breakdown: the taxable base and tax amount for a single rate.
+type InvoiceTaxLine struct {
 	gorm.Model
 	InvoiceID   uint
-	Description string
-	Quantity    float64 // supports fractional quantities (e.g., hours)
-	UnitPrice   float64
-	Total       float64 // Quantity * UnitPrice
+	TaxRate     Decimal // percentage
+	TaxableBase Decimal // item totals taxed at TaxRate, net of their share of the invoice discount
+	TaxAmount   Decimal // TaxableBase * TaxRate, rounded to the invoice currency
 }
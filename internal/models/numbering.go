@@ -0,0 +1,84 @@
+package models
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Reset policies for NumberingSeries.ResetPolicy.
+const (
+	ResetNever  = "Never"  // one sequence for the lifetime of the series
+	ResetYearly = "Yearly" // sequence restarts at 1 every fiscal year
+)
+
+// ErrInvalidNumberPattern is returned when a numbering pattern has no sequence placeholder.
+var ErrInvalidNumberPattern = errors.New("numbering pattern must contain a sequence placeholder such as {0000}")
+
+// NumberingSeries is a named numbering sequence of a company for one document type.
+//
+// Pattern placeholders:
+//
+//	{YYYY} fiscal year, e.g. 2025
+//	{YY}   two-digit fiscal year, e.g. 25
+//	{0000} sequence number, zero-padded to the number of zeros (use {0} for no padding)
+//
+// So "INV-{YYYY}-{0000}" yields "INV-2025-0001".
+type NumberingSeries struct {
+	gorm.Model
+	CompanyID    uint   `gorm:"index"`
+	Name         string // e.g. "Invoices", "Rectificativas"
+	DocumentType string // DocumentTypeInvoice, DocumentTypeCreditNote, ...
+	Pattern      string // see type documentation
+	ResetPolicy  string // ResetNever or ResetYearly
+	IsDefault    bool   // used when a document is created without an explicit series
+}
+
+// NumberingCounter holds the last number handed out by a series for a year (0 when the series never resets).
+type NumberingCounter struct {
+	gorm.Model
+	SeriesID   uint `gorm:"uniqueIndex:idx_numbering_counter_series_year"`
+	Year       int  `gorm:"uniqueIndex:idx_numbering_counter_series_year"`
+	LastNumber int
+}
+
+var sequencePlaceholder = regexp.MustCompile(`\{0+\}`)
+
+// Validate checks the pattern and reset policy of the series.
+func (s *NumberingSeries) Validate() error {
+	if !sequencePlaceholder.MatchString(s.Pattern) {
+		return ErrInvalidNumberPattern
+	}
+	if s.ResetPolicy != ResetNever && s.ResetPolicy != ResetYearly {
+		return gorm.ErrInvalidData
+	}
+	return nil
+}
+
+// CounterYear returns the counter year used for a fiscal year: the year itself for yearly series, 0 otherwise.
+func (s *NumberingSeries) CounterYear(fiscalYear int) int {
+	if s.ResetPolicy == ResetYearly {
+		return fiscalYear
+	}
+	return 0
+}
+
+// Format renders the pattern for a fiscal year and sequence number.
+func (s *NumberingSeries) Format(fiscalYear, seq int) string {
+	out := strings.ReplaceAll(s.Pattern, "{YYYY}", strconv.Itoa(fiscalYear))
+	yy := strconv.Itoa(fiscalYear % 100)
+	if len(yy) < 2 {
+		yy = "0" + yy
+	}
+	out = strings.ReplaceAll(out, "{YY}", yy)
+	return sequencePlaceholder.ReplaceAllStringFunc(out, func(m string) string {
+		n := strconv.Itoa(seq)
+		if width := len(m) - 2; len(n) < width {
+			n = strings.Repeat("0", width-len(n)) + n
+		}
+		return n
+	})
+}
@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// OpenBalance returns what is left to pay of the invoice: Total less its issued credit notes and payments.
+func (inv *Invoice) OpenBalance() Decimal {
+	return inv.Total.Sub(inv.AmountCredited).Sub(inv.AmountPaid)
+}
+
+// IsOpen reports whether the invoice still awaits payment: an issued invoice (not a draft,
+// void or credit note) whose open balance is positive.
+func (inv *Invoice) IsOpen() bool {
+	if inv.IsCreditNote() {
+		return false
+	}
+	switch inv.Status {
+	case StatusDraft, StatusPaid, StatusVoid:
+		return false
+	}
+	return inv.OpenBalance() > 0
+}
+
+// OverdueDays returns how many days past its DueDate an open invoice is on the given day,
+// or 0 if it is not overdue (or has no valid due date).
+func (inv *Invoice) OverdueDays(today time.Time) int {
+	if !inv.IsOpen() || inv.DueDate == "" {
+		return 0
+	}
+	due, err := time.Parse(time.DateOnly, inv.DueDate)
+	if err != nil {
+		return 0
+	}
+	y, m, d := today.Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	if !day.After(due) {
+		return 0
+	}
+	return int(day.Sub(due).Hours() / 24)
+}
+
+// MarkOverdue fills IsOverdue and DaysOverdue for the given day.
+func (inv *Invoice) MarkOverdue(today time.Time) {
+	inv.DaysOverdue = inv.OverdueDays(today)
+	inv.IsOverdue = inv.DaysOverdue > 0
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Payment is money received against an invoice. Voided payments are kept for the record
+// but no longer count towards the amount paid.
+type Payment struct {
+	gorm.Model
+	InvoiceID uint       `gorm:"index"`
+	Date      string     // ISO date (YYYY-MM-DD)
+	Amount    Decimal    // positive amount in the invoice currency
+	Method    string     // e.g. "Bank transfer", "Card", "Cash"
+	Reference string     // bank reference, transaction ID, cheque number...
+	Notes     string     // optional free text
+	VoidedAt  *time.Time // set when the payment was voided
+}
+
+// IsVoided reports whether the payment has been voided.
+func (p *Payment) IsVoided() bool { return p.VoidedAt != nil }
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// Payment term types for PaymentTerms.Type.
+const (
+	PaymentTermsNet        = "Net"        // due Days after the issue date
+	PaymentTermsEndOfMonth = "EndOfMonth" // due Days after the last day of the issue month
+	PaymentTermsOnReceipt  = "OnReceipt"  // due on the issue date
+)
+
+// ErrInvalidPaymentTerms is returned when payment terms have an unknown type or a day count that does not fit it.
+var ErrInvalidPaymentTerms = errors.New("invalid payment terms")
+
+// PaymentTerms describe when an invoice is due relative to its issue date. It is embedded into
+// CompanyDefaults, ClientDefaults and Invoice; the zero value means no terms (inherit, or a due date typed by hand).
+type PaymentTerms struct {
+	Type string // one of the PaymentTerms* constants; empty for none
+	Days int    // days for Net and EndOfMonth
+}
+
+// IsZero reports whether no terms are set.
+func (t PaymentTerms) IsZero() bool { return t.Type == "" }
+
+// Validate checks the type and day count.
+func (t PaymentTerms) Validate() error {
+	switch t.Type {
+	case "":
+		if t.Days != 0 {
+			return ErrInvalidPaymentTerms
+		}
+	case PaymentTermsNet:
+		if t.Days <= 0 {
+			return ErrInvalidPaymentTerms
+		}
+	case PaymentTermsEndOfMonth:
+		if t.Days < 0 {
+			return ErrInvalidPaymentTerms
+		}
+	case PaymentTermsOnReceipt:
+		if t.Days != 0 {
+			return ErrInvalidPaymentTerms
+		}
+	default:
+		return ErrInvalidPaymentTerms
+	}
+	return nil
+}
+
+// DueDate returns the due date for an invoice issued on issueDate (ISO date), or "" when no terms
+// are set or the issue date is not a valid date.
+func (t PaymentTerms) DueDate(issueDate string) string {
+	issued, err := time.Parse(time.DateOnly, issueDate)
+	if err != nil {
+		return ""
+	}
+	switch t.Type {
+	case PaymentTermsNet:
+		return issued.AddDate(0, 0, t.Days).Format(time.DateOnly)
+	case PaymentTermsEndOfMonth:
+		endOfMonth := time.Date(issued.Year(), issued.Month()+1, 0, 0, 0, 0, 0, time.UTC)
+		return endOfMonth.AddDate(0, 0, t.Days).Format(time.DateOnly)
+	case PaymentTermsOnReceipt:
+		return issueDate
+	}
+	return ""
+}
@@ -0,0 +1,127 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// RatePlaces is the number of fractional digits kept by Rate.
+const RatePlaces = 10
+
+// rateFactor is 10^RatePlaces.
+const rateFactor = 10000000000
+
+// Rate is an exchange rate with ten fractional digits, stored as a scaled integer like Decimal so
+// that ECB quotes (five decimals) and derived inverse or cross rates are kept without rounding them
+// to amount precision. In the database it is a TEXT column holding the plain decimal string, and in
+// JSON it is a plain number.
+type Rate int64
+
+// ParseRate parses a plain decimal string such as "1.08125". Extra fractional digits beyond ten
+// places are rounded half away from zero.
+func ParseRate(s string) (Rate, error) {
+	v, err := parseScaled(s, RatePlaces)
+	return Rate(v), err
+}
+
+// NewRate returns the Rate for a whole number of units.
+func NewRate(units int64) Rate { return Rate(units * rateFactor) }
+
+// Div returns r / o rounded half away from zero to ten places. Division by zero yields zero.
+func (r Rate) Div(o Rate) Rate {
+	if o == 0 {
+		return 0
+	}
+	n := new(big.Int).Mul(big.NewInt(int64(r)), big.NewInt(rateFactor))
+	return Rate(divRound(n, big.NewInt(int64(o))))
+}
+
+// String returns the shortest plain representation, e.g. "1.08125".
+func (r Rate) String() string {
+	u := uint64(r)
+	neg := r < 0
+	if neg {
+		u = uint64(-r)
+	}
+	s := strconv.FormatUint(u/rateFactor, 10)
+	if frac := strings.TrimRight(strconv.FormatUint(u%rateFactor+rateFactor, 10)[1:], "0"); frac != "" {
+		s += "." + frac
+	}
+	if neg {
+		s = "-" + s
+	}
+	return s
+}
+
+// MarshalJSON encodes r as a JSON number.
+func (r Rate) MarshalJSON() ([]byte, error) { return []byte(r.String()), nil }
+
+// UnmarshalJSON accepts a JSON number, a numeric string or null.
+func (r *Rate) UnmarshalJSON(b []byte) error {
+	s := strings.TrimSpace(string(b))
+	if s == "null" {
+		return nil
+	}
+	s = strings.Trim(s, `"`)
+	if s == "" {
+		*r = 0
+		return nil
+	}
+	v, err := ParseRate(s)
+	if err != nil {
+		return err
+	}
+	*r = v
+	return nil
+}
+
+// GormDataType stores rates as TEXT so they are readable and keep all their digits.
+func (Rate) GormDataType() string { return "text" }
+
+// Value implements driver.Valuer.
+func (r Rate) Value() (driver.Value, error) { return r.String(), nil }
+
+// Scan implements sql.Scanner.
+func (r *Rate) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*r = 0
+	case float64:
+		*r = Rate(math.Round(v * rateFactor))
+	case string:
+		return r.parse(v)
+	case []byte:
+		return r.parse(string(v))
+	default:
+		return fmt.Errorf("cannot scan %T into Rate", src)
+	}
+	return nil
+}
+
+func (r *Rate) parse(s string) error {
+	if strings.TrimSpace(s) == "" {
+		*r = 0
+		return nil
+	}
+	v, err := ParseRate(s)
+	if err != nil {
+		return err
+	}
+	*r = v
+	return nil
+}
+
+// Convert returns d * units / per rounded half away from zero to four places: the value of d
+// when per units of its currency are worth units of another. Conversions go through this
+// single rounding rather than dividing by a rounded rate. A zero per yields zero.
+func (d Decimal) Convert(units, per Rate) Decimal {
+	if per == 0 {
+		return 0
+	}
+	n := new(big.Int).Mul(big.NewInt(int64(d)), big.NewInt(int64(units)))
+	return Decimal(divRound(n, big.NewInt(int64(per))))
+}
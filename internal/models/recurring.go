@@ -0,0 +1,192 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Recurrence intervals for RecurringInvoice.Interval.
+const (
+	IntervalWeekly    = "Weekly"
+	IntervalMonthly   = "Monthly"
+	IntervalQuarterly = "Quarterly"
+	IntervalYearly    = "Yearly"
+)
+
+// ErrInvalidSchedule is returned when a recurring invoice has an unknown interval or invalid dates.
+var ErrInvalidSchedule = errors.New("invalid recurring invoice schedule")
+
+// RecurringInvoice is a template from which an invoice is issued every interval (e.g. a monthly retainer).
+type RecurringInvoice struct {
+	gorm.Model
+	CompanyID uint `gorm:"index"`
+	ClientID  uint
+	Name      string // e.g. "Hosting retainer"
+	Active    bool   // paused schedules generate nothing
+
+	// Schedule
+	Interval      string // one of the Interval* constants
+	IntervalCount int    // every N intervals; 0 or 1 means every interval
+	StartDate     string // ISO date of the first invoice; its day of month anchors monthly schedules
+	NextRunDate   string // ISO issue date of the next invoice to generate
+	EndDate       string // optional ISO date; no invoice is issued after it
+	LastRunDate   string // issue date of the last generated invoice
+	DueDays       int    // days from issue date to due date; 0 leaves DueDate empty
+
+	// Invoice template
+	SeriesID        *uint   // numbering series; nil uses the company default
+	Status          string  // status of generated invoices: StatusDraft (default) or StatusSent
+	Currency        string  // ISO 4217 code
+	TaxRate         Decimal // default percentage for items without their own rate
+	DiscountRate    Decimal
+	DiscountAmount  Decimal
+	WithholdingRate Decimal
+	Notes           *string
+	FooterText      string
+	Items           []RecurringInvoiceItem
+}
+
+// RecurringInvoiceItem is a line copied onto every invoice generated from a RecurringInvoice.
+type RecurringInvoiceItem struct {
+	gorm.Model
+	RecurringInvoiceID uint   `gorm:"index"`
+	CatalogItemID      *uint  // catalog entry the line was created from
+	Code               string // catalog code / SKU
+	Description        string
+	Quantity           Decimal
+	Unit               string // unit of measure code, see StandardUnits
+	UnitPrice          Decimal
+	TaxRate            *Decimal // percentage; nil uses the template TaxRate
+	DiscountRate       Decimal
+	DiscountAmount     Decimal
+}
+
+// Validate checks the interval and dates of the schedule, defaulting StartDate and NextRunDate to each other.
+func (r *RecurringInvoice) Validate() error {
+	switch r.Interval {
+	case IntervalWeekly, IntervalMonthly, IntervalQuarterly, IntervalYearly:
+	default:
+		return ErrInvalidSchedule
+	}
+	if r.IntervalCount < 0 || r.DueDays < 0 {
+		return ErrInvalidSchedule
+	}
+	if r.StartDate == "" {
+		r.StartDate = r.NextRunDate
+	}
+	if r.NextRunDate == "" {
+		r.NextRunDate = r.StartDate
+	}
+	for _, s := range []string{r.StartDate, r.NextRunDate} {
+		if _, err := time.Parse(time.DateOnly, s); err != nil {
+			return ErrInvalidSchedule
+		}
+	}
+	if r.EndDate != "" {
+		if _, err := time.Parse(time.DateOnly, r.EndDate); err != nil {
+			return ErrInvalidSchedule
+		}
+	}
+	return nil
+}
+
+// IsDue reports whether an invoice should be issued on NextRunDate given the current day.
+func (r *RecurringInvoice) IsDue(today time.Time) bool {
+	if !r.Active || r.NextRunDate == "" {
+		return false
+	}
+	if r.EndDate != "" && r.NextRunDate > r.EndDate {
+		return false
+	}
+	return r.NextRunDate <= today.Format(time.DateOnly)
+}
+
+// Advance moves NextRunDate one period forward. Monthly, quarterly and yearly schedules keep the
+// day of month of StartDate, clamped to the month length, so a schedule starting on the 31st
+// runs on Feb 28 and then Mar 31 again.
+func (r *RecurringInvoice) Advance() error {
+	next, err := time.Parse(time.DateOnly, r.NextRunDate)
+	if err != nil {
+		return ErrInvalidSchedule
+	}
+	n := r.IntervalCount
+	if n < 1 {
+		n = 1
+	}
+	var months int
+	switch r.Interval {
+	case IntervalWeekly:
+		r.NextRunDate = next.AddDate(0, 0, 7*n).Format(time.DateOnly)
+		return nil
+	case IntervalMonthly:
+		months = n
+	case IntervalQuarterly:
+		months = 3 * n
+	case IntervalYearly:
+		months = 12 * n
+	default:
+		return ErrInvalidSchedule
+	}
+	day := next.Day()
+	if start, err := time.Parse(time.DateOnly, r.StartDate); err == nil {
+		day = start.Day()
+	}
+	first := time.Date(next.Year(), next.Month()+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+	if last := first.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+	r.NextRunDate = time.Date(first.Year(), first.Month(), day, 0, 0, 0, 0, time.UTC).Format(time.DateOnly)
+	return nil
+}
+
+// NewInvoice builds the invoice issued on the given ISO date from the template. Numbering and
+// totals are left to the caller.
+func (r *RecurringInvoice) NewInvoice(issueDate string) (Invoice, error) {
+	issued, err := time.Parse(time.DateOnly, issueDate)
+	if err != nil {
+		return Invoice{}, ErrInvalidSchedule
+	}
+	inv := Invoice{
+		DocumentType:    DocumentTypeInvoice,
+		CompanyID:       r.CompanyID,
+		ClientID:        r.ClientID,
+		SeriesID:        r.SeriesID,
+		IssueDate:       issueDate,
+		FiscalYear:      issued.Year(),
+		Currency:        r.Currency,
+		TaxRate:         r.TaxRate,
+		DiscountRate:    r.DiscountRate,
+		DiscountAmount:  r.DiscountAmount,
+		WithholdingRate: r.WithholdingRate,
+		Status:          r.Status,
+		FooterText:      r.FooterText,
+	}
+	if r.DueDays > 0 {
+		inv.PaymentTerms = PaymentTerms{Type: PaymentTermsNet, Days: r.DueDays}
+		inv.DueDate = inv.PaymentTerms.DueDate(issueDate)
+	}
+	if r.Notes != nil {
+		notes := *r.Notes
+		inv.Notes = &notes
+	}
+	for _, it := range r.Items {
+		item := InvoiceItem{
+			CatalogItemID:  it.CatalogItemID,
+			Code:           it.Code,
+			Description:    it.Description,
+			Quantity:       it.Quantity,
+			Unit:           it.Unit,
+			UnitPrice:      it.UnitPrice,
+			DiscountRate:   it.DiscountRate,
+			DiscountAmount: it.DiscountAmount,
+		}
+		if it.TaxRate != nil {
+			rate := *it.TaxRate
+			item.TaxRate = &rate
+		}
+		inv.Items = append(inv.Items, item)
+	}
+	return inv, nil
+}
@@ -0,0 +1,217 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidStatusTransition is matched (errors.Is) by every StatusTransitionError.
+	ErrInvalidStatusTransition = errors.New("invalid invoice status transition")
+	// ErrInvoiceLocked is matched (errors.Is) by every InvoiceLockedError.
+	ErrInvoiceLocked = errors.New("invoice is locked")
+)
+
+// StatusTransitionError is returned when an invoice is moved to a status that cannot follow its current one.
+type StatusTransitionError struct {
+	From string // current status ("" for a new invoice)
+	To   string // requested status
+}
+
+func (e *StatusTransitionError) Error() string {
+	if e.From == "" {
+		return fmt.Sprintf("an invoice cannot be created with status %q", e.To)
+	}
+	return fmt.Sprintf("invoice status cannot change from %q to %q", e.From, e.To)
+}
+
+func (e *StatusTransitionError) Unwrap() error { return ErrInvalidStatusTransition }
+
+// InvoiceLockedError is returned when a change touches a financial field of an invoice that has left Draft,
+// or when such an invoice is deleted. Such invoices can only be corrected with a credit note or voided.
+type InvoiceLockedError struct {
+	Status string // current status of the invoice
+	Field  string // first locked field the change touched, e.g. "Items"; empty when deleting
+}
+
+func (e *InvoiceLockedError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("invoice is %s and can no longer be deleted", e.Status)
+	}
+	return fmt.Sprintf("invoice is %s: %s can no longer be changed", e.Status, e.Field)
+}
+
+func (e *InvoiceLockedError) Unwrap() error { return ErrInvoiceLocked }
+
+// statusTransitions lists the statuses each status may move to. Draft is the only editable status;
+// Pending is kept for invoices created by older versions and behaves like Sent. Paid and
+// PartiallyPaid may go back when payments are voided; Void is final.
+var statusTransitions = map[string][]string{
+	StatusDraft:         {StatusSent},
+	StatusPending:       {StatusSent, StatusPartiallyPaid, StatusPaid, StatusVoid},
+	StatusSent:          {StatusPartiallyPaid, StatusPaid, StatusVoid},
+	StatusPartiallyPaid: {StatusSent, StatusPaid, StatusVoid},
+	StatusPaid:          {StatusSent, StatusPartiallyPaid},
+	StatusVoid:          {},
+}
+
+// quoteTransitions lists the statuses a quote may move to. A sent quote can go back to Draft
+// for a revision; Accepted and Rejected are final.
+var quoteTransitions = map[string][]string{
+	StatusDraft:    {StatusSent},
+	StatusSent:     {StatusDraft, StatusAccepted, StatusRejected},
+	StatusAccepted: {},
+	StatusRejected: {},
+}
+
+// initialStatuses are the statuses a new document may be created with.
+var initialStatuses = []string{StatusDraft, StatusPending, StatusSent}
+
+// transitionsFor returns the transition table of a document type.
+func transitionsFor(documentType string) map[string][]string {
+	if documentType == DocumentTypeQuote {
+		return quoteTransitions
+	}
+	return statusTransitions
+}
+
+// CanTransition reports whether a document of the given type may move from one status to another.
+// Staying in the same status is always allowed; an empty from status is treated as Draft.
+func CanTransition(documentType, from, to string) bool {
+	transitions := transitionsFor(documentType)
+	if from == "" {
+		from = StatusDraft
+	}
+	if from == to {
+		_, ok := transitions[to]
+		return ok
+	}
+	for _, s := range transitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckInitialStatus defaults an empty Status to Draft and rejects statuses a new document cannot start in.
+func (inv *Invoice) CheckInitialStatus() error {
+	if inv.Status == "" {
+		inv.Status = StatusDraft
+	}
+	for _, s := range initialStatuses {
+		if _, known := transitionsFor(inv.DocumentType)[s]; known && inv.Status == s {
+			return nil
+		}
+	}
+	return &StatusTransitionError{To: inv.Status}
+}
+
+// IsLocked reports whether the financial fields of the document are immutable: invoices and credit
+// notes once they have left Draft, quotes once they have been accepted or rejected.
+func (inv *Invoice) IsLocked() bool {
+	if inv.IsQuote() {
+		return inv.Status == StatusAccepted || inv.Status == StatusRejected
+	}
+	return inv.Status != "" && inv.Status != StatusDraft
+}
+
+// CheckUpdate validates replacing the stored invoice inv with next: the status change must be
+// an allowed transition and, once inv is locked, next must not change any financial field. Items
+// of next must carry their stored IDs. Amounts of a locked invoice are never recomputed, so next
+// is compared before ComputeTotals.
+func (inv *Invoice) CheckUpdate(next *Invoice) error {
+	if next.Status == "" {
+		next.Status = inv.Status
+	}
+	if !CanTransition(inv.DocumentType, inv.Status, next.Status) {
+		return &StatusTransitionError{From: inv.Status, To: next.Status}
+	}
+	if !inv.IsLocked() {
+		return nil
+	}
+	if field := inv.changedFinancialField(next); field != "" {
+		return &InvoiceLockedError{Status: inv.Status, Field: field}
+	}
+	return nil
+}
+
+// changedFinancialField returns the name of the first financial field next changes on inv, or "".
+// Only the fields a caller enters are compared, never the amounts ComputeTotals derives, so invoices
+// totalled by older versions can still change status. Zero values (and an empty item list) leave a
+// field as stored: a caller that does not send the item unit, say, is not changing it.
+// Status, DueDate, Notes, FooterText, Language and the billing contact are not financial and may change at any time.
+// The billing address is part of the issued document and is treated as financial.
+func (inv *Invoice) changedFinancialField(next *Invoice) string {
+	switch {
+	case next.CompanyID != 0 && next.CompanyID != inv.CompanyID:
+		return "CompanyID"
+	case next.ClientID != 0 && next.ClientID != inv.ClientID:
+		return "ClientID"
+	case !sameID(next.BillingAddressID, inv.BillingAddressID):
+		return "BillingAddressID"
+	case next.Number != inv.Number || next.DisplayNumber != inv.DisplayNumber:
+		return "Number"
+	case next.IssueDate != "" && next.IssueDate != inv.IssueDate:
+		return "IssueDate"
+	case next.FiscalYear != 0 && next.FiscalYear != inv.FiscalYear:
+		return "FiscalYear"
+	case next.Currency != "" && next.Currency != inv.Currency:
+		return "Currency"
+	case next.TaxRate != 0 && next.TaxRate != inv.TaxRate:
+		return "TaxRate"
+	case next.DiscountRate != 0 && next.DiscountRate != inv.DiscountRate,
+		changedDiscount(inv.DiscountRate, inv.DiscountAmount, next.DiscountAmount):
+		return "Discount"
+	case next.WithholdingRate != 0 && next.WithholdingRate != inv.WithholdingRate:
+		return "WithholdingRate"
+	case len(next.Items) > 0 && !inv.sameItems(next):
+		return "Items"
+	}
+	return ""
+}
+
+// sameItems reports whether next holds the same lines as inv (matched by ID), comparing the fields
+// entered on each line like changedFinancialField. Tax rates are compared as applied, so an item
+// inheriting 21% equals one set to 21% explicitly; credit note quantities are compared as stored (negative).
+func (inv *Invoice) sameItems(next *Invoice) bool {
+	if len(inv.Items) != len(next.Items) {
+		return false
+	}
+	byID := make(map[uint]InvoiceItem, len(inv.Items))
+	for _, it := range inv.Items {
+		byID[it.ID] = it
+	}
+	for _, it := range next.Items {
+		old, ok := byID[it.ID]
+		quantity := it.Quantity
+		if inv.IsCreditNote() {
+			quantity = quantity.Abs().Neg()
+		}
+		if !ok ||
+			old.Description != it.Description ||
+			old.Quantity != quantity ||
+			old.UnitPrice != it.UnitPrice ||
+			it.Unit != "" && old.Unit != it.Unit ||
+			it.TaxRate != nil && inv.ItemTaxRate(old) != *it.TaxRate ||
+			it.DiscountRate != 0 && old.DiscountRate != it.DiscountRate ||
+			changedDiscount(old.DiscountRate, old.DiscountAmount, it.DiscountAmount) {
+			return false
+		}
+	}
+	return true
+}
+
+// changedDiscount reports whether a given absolute discount differs from the stored one. Discounts
+// derived from a rate are not compared, only the rate they come from.
+func changedDiscount(storedRate, stored, given Decimal) bool {
+	return storedRate == 0 && given != 0 && given != stored
+}
+
+// sameID reports whether two optional foreign keys point to the same record.
+func sameID(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TimeEntry is time worked for a client. Billable entries that are not invoiced yet are turned into
+// invoice lines by DatabaseService.InvoiceTimeEntries.
+type TimeEntry struct {
+	gorm.Model
+	CompanyID   uint    `gorm:"index"`
+	ClientID    uint    `gorm:"index"`
+	Date        string  // ISO date the work was done
+	Duration    Decimal // hours, e.g. 1.5
+	Description string  // entries with the same description and rate become one invoice line
+	Rate        Decimal // hourly rate in the invoice currency
+	Billable    bool    `gorm:"default:true"`
+	Invoiced    bool    `gorm:"index"`
+	InvoiceID   *uint   `gorm:"index"` // invoice the entry was billed on
+}
+
+// Normalize trims the text fields.
+func (e *TimeEntry) Normalize() {
+	e.Date = strings.TrimSpace(e.Date)
+	e.Description = strings.TrimSpace(e.Description)
+}
+
+// Validate checks the date, and that duration and rate are not negative.
+func (e *TimeEntry) Validate() error {
+	if _, err := time.Parse(time.DateOnly, e.Date); err != nil {
+		return gorm.ErrInvalidData
+	}
+	if e.Duration < 0 || e.Rate < 0 {
+		return gorm.ErrInvalidData
+	}
+	return nil
+}
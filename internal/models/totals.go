@@ -0,0 +1,129 @@
+package models
+
+import (
+	"errors"
+	"sort"
+)
+
+var (
+	// ErrInvalidTaxRate is returned when a tax rate is outside 0..100 percent.
+	ErrInvalidTaxRate = errors.New("tax rate must be between 0 and 100")
+	// ErrInvalidDiscount is returned when a discount is negative or its rate is outside 0..100 percent.
+	// Only credit notes carry negative discounts.
+	ErrInvalidDiscount = errors.New("discount must be between 0 and 100 percent and not negative")
+	// ErrInvalidWithholdingRate is returned when a withholding rate is outside 0..100 percent.
+	ErrInvalidWithholdingRate = errors.New("withholding rate must be between 0 and 100")
+)
+
+// maxRate is 100% expressed as a Decimal.
+var maxRate = NewDecimal(100)
+
+func validRate(r Decimal) bool { return r >= 0 && r <= maxRate }
+
+// validDiscount reports whether an absolute discount is allowed on inv: credit notes store their
+// discounts negative, every other document must not have a negative one.
+func validDiscount(inv *Invoice, d Decimal) bool { return d >= 0 || inv.IsCreditNote() }
+
+// ItemTaxRate returns the tax rate applied to an item: its own rate if set, otherwise the invoice rate.
+func (inv *Invoice) ItemTaxRate(it InvoiceItem) Decimal {
+	if it.TaxRate != nil {
+		return *it.TaxRate
+	}
+	return inv.TaxRate
+}
+
+// discountOf returns the discount for an amount: rate percent of it when a rate
+// is set, otherwise the given absolute amount, rounded to the currency. The
+// result always has the sign of amount, so credit note discounts are negative.
+func discountOf(amount, rate, absolute Decimal, currency string) Decimal {
+	if rate > 0 {
+		return amount.Percent(rate).RoundCurrency(currency)
+	}
+	d := absolute.Abs().RoundCurrency(currency)
+	if amount < 0 {
+		return d.Neg()
+	}
+	return d
+}
+
+// ComputeTotals derives every calculated amount of the invoice from its lines:
+// each item DiscountAmount and Total, Subtotal, the invoice DiscountAmount, the
+// per-rate TaxLines, TaxAmount, WithholdingAmount and Total.
+//
+// Discounts are applied before tax: line discounts reduce the item total, and
+// the invoice-level discount is spread over the tax rates in proportion to
+// their base. Tax is then computed once per rate over the net taxable base, as
+// EU VAT rules require, and withholding is taken from the same base. All
+// amounts are rounded to the minor unit of the invoice currency so any caller
+// gets identical numbers. Values previously set on those fields are overwritten.
+//
+// Credit notes always carry negative amounts: their quantities are made negative
+// before anything else is computed, and their discounts take the sign of the
+// amount they reduce. Other documents reject negative discounts.
+func (inv *Invoice) ComputeTotals() error {
+	if !validRate(inv.TaxRate) {
+		return ErrInvalidTaxRate
+	}
+	if !validRate(inv.DiscountRate) || !validDiscount(inv, inv.DiscountAmount) {
+		return ErrInvalidDiscount
+	}
+	if !validRate(inv.WithholdingRate) {
+		return ErrInvalidWithholdingRate
+	}
+
+	cur := inv.Currency
+	var subtotal Decimal
+	bases := map[Decimal]Decimal{}
+	for i := range inv.Items {
+		it := &inv.Items[i]
+		rate := inv.ItemTaxRate(*it)
+		if !validRate(rate) {
+			return ErrInvalidTaxRate
+		}
+		if !validRate(it.DiscountRate) || !validDiscount(inv, it.DiscountAmount) {
+			return ErrInvalidDiscount
+		}
+		if inv.IsCreditNote() {
+			it.Quantity = it.Quantity.Abs().Neg()
+		}
+		gross := it.Quantity.Mul(it.UnitPrice).RoundCurrency(cur)
+		it.DiscountAmount = discountOf(gross, it.DiscountRate, it.DiscountAmount, cur)
+		it.Total = gross.Sub(it.DiscountAmount)
+		subtotal = subtotal.Add(it.Total)
+		bases[rate] = bases[rate].Add(it.Total)
+	}
+	inv.Subtotal = subtotal
+	inv.DiscountAmount = discountOf(subtotal, inv.DiscountRate, inv.DiscountAmount, cur)
+
+	rates := make([]Decimal, 0, len(bases))
+	for r := range bases {
+		rates = append(rates, r)
+	}
+	sort.Slice(rates, func(i, j int) bool { return rates[i] > rates[j] })
+
+	// Spread the invoice discount across rates; the last rate absorbs rounding.
+	var taxAmount, allocated Decimal
+	inv.TaxLines = make([]InvoiceTaxLine, 0, len(rates))
+	for i, r := range rates {
+		share := inv.DiscountAmount.Sub(allocated)
+		if i < len(rates)-1 {
+			share = inv.DiscountAmount.Mul(bases[r]).Div(subtotal).RoundCurrency(cur)
+		}
+		allocated = allocated.Add(share)
+		base := bases[r].Sub(share)
+		line := InvoiceTaxLine{
+			InvoiceID:   inv.ID,
+			TaxRate:     r,
+			TaxableBase: base,
+			TaxAmount:   base.Percent(r).RoundCurrency(cur),
+		}
+		taxAmount = taxAmount.Add(line.TaxAmount)
+		inv.TaxLines = append(inv.TaxLines, line)
+	}
+
+	taxable := subtotal.Sub(inv.DiscountAmount)
+	inv.TaxAmount = taxAmount
+	inv.WithholdingAmount = taxable.Percent(inv.WithholdingRate).RoundCurrency(cur)
+	inv.Total = taxable.Add(taxAmount).Sub(inv.WithholdingAmount)
+	return nil
+}
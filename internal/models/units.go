@@ -0,0 +1,48 @@
+package models
+
+import "errors"
+
+// Units of measure for InvoiceItem.Unit and CatalogItem.Unit, as UN/ECE Recommendation 20 common
+// codes so they can be used unchanged in e-invoicing formats (UBL unitCode, CII unitCode).
+// Labels are localized in the "units" section of the locale files.
+const (
+	UnitPiece      = "H87" // piece
+	UnitOne        = "C62" // one (generic unit)
+	UnitSet        = "SET"
+	UnitLumpSum    = "LS"
+	UnitMinute     = "MIN"
+	UnitHour       = "HUR"
+	UnitDay        = "DAY"
+	UnitWeek       = "WEE"
+	UnitMonth      = "MON"
+	UnitYear       = "ANN"
+	UnitKilogram   = "KGM"
+	UnitMetre      = "MTR"
+	UnitKilometre  = "KMT"
+	UnitSquareMtr  = "MTK"
+	UnitLitre      = "LTR"
+	UnitKilowattHr = "KWH"
+)
+
+// StandardUnits lists the supported unit codes in the order they are offered to the user.
+var StandardUnits = []string{
+	UnitHour, UnitDay, UnitWeek, UnitMonth, UnitYear, UnitMinute,
+	UnitPiece, UnitOne, UnitSet, UnitLumpSum,
+	UnitKilogram, UnitMetre, UnitKilometre, UnitSquareMtr, UnitLitre, UnitKilowattHr,
+}
+
+// ErrInvalidUnit is returned when a unit is not one of StandardUnits.
+var ErrInvalidUnit = errors.New("unknown unit of measure")
+
+// IsValidUnit reports whether code is empty (no unit) or one of StandardUnits.
+func IsValidUnit(code string) bool {
+	if code == "" {
+		return true
+	}
+	for _, u := range StandardUnits {
+		if u == code {
+			return true
+		}
+	}
+	return false
+}
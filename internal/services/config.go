@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/fossinvoice/fossinvoice/internal/i18n"
+	"github.com/fossinvoice/fossinvoice/internal/models"
 )
 
 // AppConfig holds user-level application settings.
@@ -70,6 +71,28 @@ func saveConfig(cfg AppConfig) error {
 	return os.WriteFile(p, data, 0o600)
 }
 
+// UnitOption is a unit of measure offered to the user: its UN/ECE Rec 20 code and localized name.
+type UnitOption struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+// ListUnits returns the standard units of measure with names in the given language
+// (the persisted language when empty).
+func (s *ConfigService) ListUnits(lang string) []UnitOption {
+	if strings.TrimSpace(lang) == "" {
+		if cfg, err := loadConfig(); err == nil {
+			lang = cfg.Language
+		}
+	}
+	tr := i18n.T(lang)
+	units := make([]UnitOption, 0, len(models.StandardUnits))
+	for _, code := range models.StandardUnits {
+		units = append(units, UnitOption{Code: code, Name: tr("units." + code + ".name")})
+	}
+	return units
+}
+
 // GetLanguage returns the persisted language or empty string if not set.
 func (s *ConfigService) GetLanguage() (string, error) {
 	cfg, err := loadConfig()
@@ -1,6 +1,10 @@
 package services
 
 import (
+	"log"
+	"slices"
+	"time"
+
 	appdb "github.com/fossinvoice/fossinvoice/internal/db"
 	"github.com/fossinvoice/fossinvoice/internal/models"
 	"gorm.io/gorm"
@@ -10,6 +14,9 @@ import (
 // Each method opens the database at the provided path, performs the operation, and closes it.
 type DatabaseService struct{}
 
+// Init opens (creating and migrating if needed) the database, then issues any recurring invoices
+// that fell due since the database was last opened. A failing schedule is logged and does not
+// prevent the database from opening.
 func (s *DatabaseService) Init(databasePath string) error {
 	d, err := appdb.Open(databasePath)
 	if err != nil {
@@ -17,7 +24,13 @@ func (s *DatabaseService) Init(databasePath string) error {
 	}
 	defer d.Close()
 
-	return d.DB.Exec("SELECT 1").Error
+	if err := d.DB.Exec("SELECT 1").Error; err != nil {
+		return err
+	}
+	if _, err := generateRecurringInvoices(d.DB, 0, time.Now()); err != nil {
+		log.Printf("recurring invoices: %v", err)
+	}
+	return nil
 }
 
 // ListCompanies returns all companies.
@@ -112,13 +125,56 @@ func (s *DatabaseService) DeleteCompany(databasePath string, companyID uint) err
 		if err := tx.Where("invoice_id IN (?)", subInvoices).Delete(&models.InvoiceItem{}).Error; err != nil {
 			return err
 		}
+		if err := tx.Where("invoice_id IN (?)", subInvoices).Delete(&models.InvoiceTaxLine{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("invoice_id IN (?)", subInvoices).Delete(&models.Payment{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("invoice_id IN (?)", subInvoices).Delete(&models.Attachment{}).Error; err != nil {
+			return err
+		}
+
+		// Delete recurring schedules for the company
+		subRecurring := tx.Model(&models.RecurringInvoice{}).Select("id").Where("company_id = ?", companyID)
+		if err := tx.Where("recurring_invoice_id IN (?)", subRecurring).Delete(&models.RecurringInvoiceItem{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("company_id = ?", companyID).Delete(&models.RecurringInvoice{}).Error; err != nil {
+			return err
+		}
+
+		// Delete the company catalog and bank accounts
+		if err := tx.Where("company_id = ?", companyID).Delete(&models.CatalogItem{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("company_id = ?", companyID).Delete(&models.BankAccount{}).Error; err != nil {
+			return err
+		}
+		// Expenses are removed for good so their receipts do not stay behind in the database
+		if err := tx.Unscoped().Where("company_id = ?", companyID).Delete(&models.Expense{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("company_id = ?", companyID).Delete(&models.TimeEntry{}).Error; err != nil {
+			return err
+		}
 
 		// Delete invoices for the company
 		if err := tx.Where("company_id = ?", companyID).Delete(&models.Invoice{}).Error; err != nil {
 			return err
 		}
 
-		// Delete clients for the company
+		// Delete clients for the company with their defaults, additional contacts and addresses
+		subClients := tx.Model(&models.Client{}).Select("id").Where("company_id = ?", companyID)
+		if err := tx.Where("client_id IN (?)", subClients).Delete(&models.ClientDefaults{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("client_id IN (?)", subClients).Delete(&models.ClientContact{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("client_id IN (?)", subClients).Delete(&models.ClientAddress{}).Error; err != nil {
+			return err
+		}
 		if err := tx.Where("company_id = ?", companyID).Delete(&models.Client{}).Error; err != nil {
 			return err
 		}
@@ -185,7 +241,7 @@ func (s *DatabaseService) GetClient(databasePath string, clientID uint) (*models
 	defer d.Close()
 
 	var client models.Client
-	if err := d.DB.First(&client, clientID).Error; err != nil {
+	if err := d.DB.Preload("Contacts").Preload("Addresses").First(&client, clientID).Error; err != nil {
 		return nil, err
 	}
 	return &client, nil
@@ -218,7 +274,8 @@ func (s *DatabaseService) UpdateClient(databasePath string, client models.Client
 		return nil, gorm.ErrMissingWhereClause
 	}
 
-	if err := d.DB.Save(&client).Error; err != nil {
+	// Contacts and addresses are managed with their own methods
+	if err := d.DB.Omit("Contacts", "Addresses").Save(&client).Error; err != nil {
 		return nil, err
 	}
 	return &client, nil
@@ -238,12 +295,44 @@ func (s *DatabaseService) DeleteClient(databasePath string, clientID uint) error
 		if err := tx.Where("invoice_id IN (?)", subInvoices).Delete(&models.InvoiceItem{}).Error; err != nil {
 			return err
 		}
+		if err := tx.Where("invoice_id IN (?)", subInvoices).Delete(&models.InvoiceTaxLine{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("invoice_id IN (?)", subInvoices).Delete(&models.Payment{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("invoice_id IN (?)", subInvoices).Delete(&models.Attachment{}).Error; err != nil {
+			return err
+		}
+
+		// Delete recurring schedules for the client
+		subRecurring := tx.Model(&models.RecurringInvoice{}).Select("id").Where("client_id = ?", clientID)
+		if err := tx.Where("recurring_invoice_id IN (?)", subRecurring).Delete(&models.RecurringInvoiceItem{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("client_id = ?", clientID).Delete(&models.RecurringInvoice{}).Error; err != nil {
+			return err
+		}
 
 		// Delete invoices for the client
 		if err := tx.Where("client_id = ?", clientID).Delete(&models.Invoice{}).Error; err != nil {
 			return err
 		}
 
+		// Delete the client's defaults, additional contacts and addresses
+		if err := tx.Where("client_id = ?", clientID).Delete(&models.ClientDefaults{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("client_id = ?", clientID).Delete(&models.TimeEntry{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("client_id = ?", clientID).Delete(&models.ClientContact{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("client_id = ?", clientID).Delete(&models.ClientAddress{}).Error; err != nil {
+			return err
+		}
+
 		// Finally delete the client
 		if err := tx.Where("id = ?", clientID).Delete(&models.Client{}).Error; err != nil {
 			return err
@@ -256,8 +345,8 @@ func (s *DatabaseService) DeleteClient(databasePath string, clientID uint) error
 // Invoices CRUD
 // ==============================
 
-// ListInvoices returns invoices for a company with optional filters.
-// If fiscalYear > 0, filters by FiscalYear. If clientID > 0, filters by ClientID.
+// ListInvoices returns invoices (and credit notes) for a company with optional filters.
+// If fiscalYear > 0, filters by FiscalYear. If clientID > 0, filters by ClientID. Quotes are listed with ListQuotes.
 func (s *DatabaseService) ListInvoices(databasePath string, companyID uint, fiscalYear int, clientID uint) ([]models.Invoice, error) {
 	d, err := appdb.Open(databasePath)
 	if err != nil {
@@ -266,7 +355,7 @@ func (s *DatabaseService) ListInvoices(databasePath string, companyID uint, fisc
 	defer d.Close()
 
 	var invoices []models.Invoice
-	q := d.DB.Where("company_id = ?", companyID)
+	q := d.DB.Where("company_id = ? AND document_type <> ?", companyID, models.DocumentTypeQuote)
 	if fiscalYear > 0 {
 		q = q.Where("fiscal_year = ?", fiscalYear)
 	}
@@ -276,6 +365,7 @@ func (s *DatabaseService) ListInvoices(databasePath string, companyID uint, fisc
 	if err := q.Order("created_at DESC").Find(&invoices).Error; err != nil {
 		return nil, err
 	}
+	markOverdue(invoices)
 	return invoices, nil
 }
 
@@ -287,20 +377,24 @@ type InvoicesPage struct {
 
 // ListInvoicesPaged returns invoices for a company with optional filters and pagination.
 // If fiscalYear > 0, filters by FiscalYear. If clientID > 0, filters by ClientID.
-func (s *DatabaseService) ListInvoicesPaged(databasePath string, companyID uint, fiscalYear int, clientID uint, limit, offset int) (*InvoicesPage, error) {
+// If overdueOnly is set, only open invoices past their due date are returned, most overdue first.
+func (s *DatabaseService) ListInvoicesPaged(databasePath string, companyID uint, fiscalYear int, clientID uint, overdueOnly bool, limit, offset int) (*InvoicesPage, error) {
 	d, err := appdb.Open(databasePath)
 	if err != nil {
 		return nil, err
 	}
 	defer d.Close()
 
-	base := d.DB.Model(&models.Invoice{}).Where("company_id = ?", companyID)
+	base := d.DB.Model(&models.Invoice{}).Where("company_id = ? AND document_type <> ?", companyID, models.DocumentTypeQuote)
 	if fiscalYear > 0 {
 		base = base.Where("fiscal_year = ?", fiscalYear)
 	}
 	if clientID > 0 {
 		base = base.Where("client_id = ?", clientID)
 	}
+	if overdueOnly {
+		base = whereOverdue(base, time.Now())
+	}
 
 	var total int64
 	if err := base.Count(&total).Error; err != nil {
@@ -309,12 +403,16 @@ func (s *DatabaseService) ListInvoicesPaged(databasePath string, companyID uint,
 
 	var items []models.Invoice
 	q := base.Order("created_at DESC")
+	if overdueOnly {
+		q = base.Order("due_date ASC, created_at DESC")
+	}
 	if limit > 0 {
 		q = q.Limit(limit).Offset(offset)
 	}
 	if err := q.Find(&items).Error; err != nil {
 		return nil, err
 	}
+	markOverdue(items)
 	return &InvoicesPage{Items: items, Total: total}, nil
 }
 
@@ -327,17 +425,18 @@ func (s *DatabaseService) ListClientInvoices(databasePath string, companyID, cli
 	defer d.Close()
 
 	var invoices []models.Invoice
-	q := d.DB.Where("company_id = ? AND client_id = ?", companyID, clientID)
+	q := d.DB.Where("company_id = ? AND client_id = ? AND document_type <> ?", companyID, clientID, models.DocumentTypeQuote)
 	if fiscalYear > 0 {
 		q = q.Where("fiscal_year = ?", fiscalYear)
 	}
 	if err := q.Order("created_at DESC").Find(&invoices).Error; err != nil {
 		return nil, err
 	}
+	markOverdue(invoices)
 	return invoices, nil
 }
 
-// GetInvoice returns a single invoice with its items preloaded.
+// GetInvoice returns a single invoice with its items, tax breakdown and payments preloaded.
 func (s *DatabaseService) GetInvoice(databasePath string, invoiceID uint) (*models.Invoice, error) {
 	d, err := appdb.Open(databasePath)
 	if err != nil {
@@ -346,14 +445,29 @@ func (s *DatabaseService) GetInvoice(databasePath string, invoiceID uint) (*mode
 	defer d.Close()
 
 	var inv models.Invoice
-	if err := d.DB.Preload("Items").First(&inv, invoiceID).Error; err != nil {
+	if err := d.DB.Preload("Items").Preload("TaxLines").Preload("Payments").First(&inv, invoiceID).Error; err != nil {
 		return nil, err
 	}
+	inv.MarkOverdue(time.Now())
 	return &inv, nil
 }
 
 // CreateInvoice inserts a new invoice (and its items) ensuring the client belongs to the company.
+// Item totals, subtotal, tax and grand total are recomputed from the lines; values sent by the caller are ignored.
+// Leave Number at 0 to allocate it from SeriesID or the company's default series (see assignNumber);
+// drafts get their number only when they are issued.
+// New invoices start as Draft (the default) or Sent. Credit notes are created with CreateCreditNote instead.
 func (s *DatabaseService) CreateInvoice(databasePath string, invoice models.Invoice) (*models.Invoice, error) {
+	invoice.DocumentType = models.DocumentTypeInvoice
+	invoice.OriginalInvoiceID = nil
+	invoice.QuoteID = nil
+	invoice.ValidUntil = ""
+	invoice.AmountPaid, invoice.AmountCredited = 0, 0
+	invoice.Payments = nil
+	if err := invoice.CheckInitialStatus(); err != nil {
+		return nil, err
+	}
+
 	d, err := appdb.Open(databasePath)
 	if err != nil {
 		return nil, err
@@ -371,21 +485,7 @@ func (s *DatabaseService) CreateInvoice(databasePath string, invoice models.Invo
 
 	// Use a transaction to create invoice and its items
 	err = d.DB.Transaction(func(tx *gorm.DB) error {
-		// detach items for manual insert after invoice ID is known
-		items := invoice.Items
-		invoice.Items = nil
-		if err := tx.Create(&invoice).Error; err != nil {
-			return err
-		}
-		if len(items) > 0 {
-			for i := range items {
-				items[i].InvoiceID = invoice.ID
-			}
-			if err := tx.Create(&items).Error; err != nil {
-				return err
-			}
-		}
-		return nil
+		return insertInvoice(tx, &invoice)
 	})
 	if err != nil {
 		return nil, err
@@ -393,7 +493,65 @@ func (s *DatabaseService) CreateInvoice(databasePath string, invoice models.Invo
 	return &invoice, nil
 }
 
+// insertInvoice numbers a new invoice and inserts it with its items and tax lines inside tx.
+// Blank fields are filled from the company and client defaults first and the totals recomputed.
+func insertInvoice(tx *gorm.DB, invoice *models.Invoice) error {
+	if err := applyInvoiceDefaults(tx, invoice); err != nil {
+		return err
+	}
+	if err := invoice.ComputeTotals(); err != nil {
+		return err
+	}
+	if err := checkItemUnits(invoice.Items); err != nil {
+		return err
+	}
+	if err := checkCatalogRefs(tx, invoice.CompanyID, invoice.Items); err != nil {
+		return err
+	}
+	if err := resolveBillingRefs(tx, invoice); err != nil {
+		return err
+	}
+	// A rate given with a new document was entered by hand
+	invoice.ExchangeRateManual = invoice.ExchangeRate > 0
+	if err := snapshotExchangeRate(tx, invoice); err != nil {
+		return err
+	}
+	if err := numberNewDocument(tx, invoice); err != nil {
+		return err
+	}
+
+	// detach items and tax lines for manual insert after invoice ID is known
+	items, taxLines := invoice.Items, invoice.TaxLines
+	invoice.Items, invoice.TaxLines = nil, nil
+	if err := tx.Create(invoice).Error; err != nil {
+		return err
+	}
+	if len(items) > 0 {
+		for i := range items {
+			items[i].InvoiceID = invoice.ID
+		}
+		if err := tx.Create(&items).Error; err != nil {
+			return err
+		}
+	}
+	if err := replaceTaxLines(tx, invoice.ID, taxLines); err != nil {
+		return err
+	}
+	invoice.Items, invoice.TaxLines = items, taxLines
+	return nil
+}
+
 // UpdateInvoice updates invoice header fields and replaces items with provided ones (idempotent) in a transaction.
+// Like CreateInvoice, all calculated amounts of a draft are recomputed from the lines before saving.
+//
+// Status changes must follow the allowed transitions (Draft → Sent → Paid/Void), otherwise a
+// *models.StatusTransitionError is returned. Once an invoice has left Draft only its status, due date,
+// notes, footer, billing contact and bank account can change; everything else is kept as stored, and
+// giving a different value returns a *models.InvoiceLockedError (see models.Invoice.CheckUpdate).
+// Quotes are updated here too; they follow Draft → Sent → Accepted/Rejected and lock once decided.
+//
+// When the issue date or payment terms change, the due date is recomputed from the terms. Leaving
+// PaymentTerms zero keeps the stored terms, and leaving Language empty the stored language.
 func (s *DatabaseService) UpdateInvoice(databasePath string, invoice models.Invoice) (*models.Invoice, error) {
 	d, err := appdb.Open(databasePath)
 	if err != nil {
@@ -405,6 +563,60 @@ func (s *DatabaseService) UpdateInvoice(databasePath string, invoice models.Invo
 		return nil, gorm.ErrMissingWhereClause
 	}
 
+	// Document type, credited invoice and series-allocated numbers are fixed at creation time
+	var stored models.Invoice
+	if err := d.DB.Preload("Items").First(&stored, invoice.ID).Error; err != nil {
+		return nil, err
+	}
+	invoice.DocumentType = stored.DocumentType
+	invoice.OriginalInvoiceID = stored.OriginalInvoiceID
+	invoice.QuoteID = stored.QuoteID
+	invoice.SeriesID = stored.SeriesID
+	if invoice.BillingContactID == nil {
+		invoice.BillingContactID = stored.BillingContactID
+	}
+	if invoice.BillingAddressID == nil {
+		invoice.BillingAddressID = stored.BillingAddressID
+	}
+	if invoice.PaymentTerms.IsZero() {
+		invoice.PaymentTerms = stored.PaymentTerms
+	}
+	if invoice.BankAccountID == nil {
+		invoice.BankAccountID = stored.BankAccountID
+	}
+	if invoice.Language == "" {
+		invoice.Language = stored.Language
+	}
+	if err := invoice.PaymentTerms.Validate(); err != nil {
+		return nil, err
+	}
+	computeDueDate(&stored, &invoice)
+	// A zero Number keeps the stored one; only numbers outside a series can be changed by hand
+	if stored.SeriesID != nil || invoice.Number <= 0 || invoice.Number == stored.Number {
+		invoice.Number = stored.Number
+		invoice.DisplayNumber = stored.DisplayNumber
+	} else {
+		invoice.DisplayNumber = itoa(invoice.Number)
+	}
+	if err := stored.CheckUpdate(&invoice); err != nil {
+		return nil, err
+	}
+	if stored.IsLocked() {
+		return updateIssuedInvoice(d.DB, &stored, &invoice)
+	}
+	// The rate snapshot follows the stored rates unless a rate was entered by hand
+	switch {
+	case invoice.ExchangeRate > 0 && invoice.ExchangeRate != stored.ExchangeRate:
+		invoice.ExchangeRateManual = true
+	case stored.ExchangeRateManual && invoice.Currency == stored.Currency:
+		invoice.BaseCurrency, invoice.ExchangeRate, invoice.ExchangeRateManual = stored.BaseCurrency, stored.ExchangeRate, true
+	default:
+		invoice.ExchangeRateManual = false
+	}
+	if err := invoice.ComputeTotals(); err != nil {
+		return nil, err
+	}
+
 	// Validate client belongs to company if both provided
 	if invoice.ClientID != 0 {
 		var client models.Client
@@ -417,23 +629,74 @@ func (s *DatabaseService) UpdateInvoice(databasePath string, invoice models.Invo
 	}
 
 	err = d.DB.Transaction(func(tx *gorm.DB) error {
+		switch {
+		case invoice.DisplayNumber != "":
+			if err := ensureNumberFree(tx, &invoice); err != nil {
+				return err
+			}
+		case invoice.Status != models.StatusDraft:
+			// Drafts are numbered when they are issued
+			if err := assignNumber(tx, &invoice); err != nil {
+				return err
+			}
+		}
+		if err := checkItemUnits(invoice.Items); err != nil {
+			return err
+		}
+		if err := checkCatalogRefs(tx, invoice.CompanyID, invoice.Items); err != nil {
+			return err
+		}
+		if err := resolveBillingRefs(tx, &invoice); err != nil {
+			return err
+		}
+		if err := checkBankAccount(tx, invoice.CompanyID, invoice.BankAccountID); err != nil {
+			return err
+		}
+		if err := snapshotExchangeRate(tx, &invoice); err != nil {
+			return err
+		}
+		if invoice.IsCreditNote() && invoice.OriginalInvoiceID != nil {
+			var orig models.Invoice
+			if err := tx.First(&orig, *invoice.OriginalInvoiceID).Error; err != nil {
+				return err
+			}
+			if err := checkCreditLimit(tx, &orig, &invoice); err != nil {
+				return err
+			}
+		}
+
 		// 1) Update invoice header (avoid association saves)
 		if err := tx.Model(&models.Invoice{}).Where("id = ?", invoice.ID).Updates(map[string]any{
-			"company_id":      invoice.CompanyID,
-			"client_id":       invoice.ClientID,
-			"number":          invoice.Number,
-			"fiscal_year":     invoice.FiscalYear,
-			"issue_date":      invoice.IssueDate,
-			"due_date":        invoice.DueDate,
-			"currency":        invoice.Currency,
-			"subtotal":        invoice.Subtotal,
-			"tax_rate":        invoice.TaxRate,
-			"tax_amount":      invoice.TaxAmount,
-			"discount_amount": invoice.DiscountAmount,
-			"total":           invoice.Total,
-			"status":          invoice.Status,
-			"notes":           invoice.Notes,
-			"footer_text":     invoice.FooterText,
+			"company_id":           invoice.CompanyID,
+			"client_id":            invoice.ClientID,
+			"billing_contact_id":   invoice.BillingContactID,
+			"bank_account_id":      invoice.BankAccountID,
+			"billing_address_id":   invoice.BillingAddressID,
+			"series_id":            invoice.SeriesID,
+			"number":               invoice.Number,
+			"display_number":       invoice.DisplayNumber,
+			"fiscal_year":          invoice.FiscalYear,
+			"issue_date":           invoice.IssueDate,
+			"due_date":             invoice.DueDate,
+			"valid_until":          invoice.ValidUntil,
+			"payment_terms_type":   invoice.PaymentTerms.Type,
+			"payment_terms_days":   invoice.PaymentTerms.Days,
+			"currency":             invoice.Currency,
+			"base_currency":        invoice.BaseCurrency,
+			"exchange_rate":        invoice.ExchangeRate,
+			"exchange_rate_manual": invoice.ExchangeRateManual,
+			"subtotal":             invoice.Subtotal,
+			"tax_rate":             invoice.TaxRate,
+			"tax_amount":           invoice.TaxAmount,
+			"discount_rate":        invoice.DiscountRate,
+			"discount_amount":      invoice.DiscountAmount,
+			"withholding_rate":     invoice.WithholdingRate,
+			"withholding_amount":   invoice.WithholdingAmount,
+			"total":                invoice.Total,
+			"status":               invoice.Status,
+			"notes":                invoice.Notes,
+			"footer_text":          invoice.FooterText,
+			"language":             invoice.Language,
 		}).Error; err != nil {
 			return err
 		}
@@ -456,11 +719,17 @@ func (s *DatabaseService) UpdateInvoice(databasePath string, invoice models.Invo
 			if it.ID == 0 {
 				// New item
 				ni := models.InvoiceItem{
-					InvoiceID:   invoice.ID,
-					Description: it.Description,
-					Quantity:    it.Quantity,
-					UnitPrice:   it.UnitPrice,
-					Total:       it.Total,
+					InvoiceID:      invoice.ID,
+					CatalogItemID:  it.CatalogItemID,
+					Code:           it.Code,
+					Description:    it.Description,
+					Quantity:       it.Quantity,
+					Unit:           it.Unit,
+					UnitPrice:      it.UnitPrice,
+					TaxRate:        it.TaxRate,
+					DiscountRate:   it.DiscountRate,
+					DiscountAmount: it.DiscountAmount,
+					Total:          it.Total,
 				}
 				if err := tx.Create(&ni).Error; err != nil {
 					return err
@@ -471,10 +740,16 @@ func (s *DatabaseService) UpdateInvoice(databasePath string, invoice models.Invo
 				if err := tx.Model(&models.InvoiceItem{}).
 					Where("id = ? AND invoice_id = ?", it.ID, invoice.ID).
 					Updates(map[string]any{
-						"description": it.Description,
-						"quantity":    it.Quantity,
-						"unit_price":  it.UnitPrice,
-						"total":       it.Total,
+						"catalog_item_id": it.CatalogItemID,
+						"code":            it.Code,
+						"description":     it.Description,
+						"quantity":        it.Quantity,
+						"unit":            it.Unit,
+						"unit_price":      it.UnitPrice,
+						"tax_rate":        it.TaxRate,
+						"discount_rate":   it.DiscountRate,
+						"discount_amount": it.DiscountAmount,
+						"total":           it.Total,
 					}).Error; err != nil {
 					return err
 				}
@@ -490,7 +765,14 @@ func (s *DatabaseService) UpdateInvoice(databasePath string, invoice models.Invo
 				}
 			}
 		}
-		return nil
+
+		// 3) Replace the tax breakdown
+		if err := replaceTaxLines(tx, invoice.ID, invoice.TaxLines); err != nil {
+			return err
+		}
+
+		// 4) Re-derive amount paid and payment status against the new total
+		return syncPayments(tx, invoice.ID)
 	})
 	if err != nil {
 		return nil, err
@@ -498,7 +780,78 @@ func (s *DatabaseService) UpdateInvoice(databasePath string, invoice models.Invo
 	return &invoice, nil
 }
 
-// DeleteInvoice deletes an invoice and its items in a transaction.
+// updateIssuedInvoice saves the fields that stay editable once an invoice has left Draft
+// and returns the reloaded invoice.
+func updateIssuedInvoice(db *gorm.DB, stored, invoice *models.Invoice) (*models.Invoice, error) {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := resolveBillingRefs(tx, invoice); err != nil {
+			return err
+		}
+		if err := checkBankAccount(tx, stored.CompanyID, invoice.BankAccountID); err != nil {
+			return err
+		}
+		if err := tx.Model(&models.Invoice{}).Where("id = ?", stored.ID).Updates(map[string]any{
+			"billing_contact_id": invoice.BillingContactID,
+			"bank_account_id":    invoice.BankAccountID,
+			"status":             invoice.Status,
+			"due_date":           invoice.DueDate,
+			"valid_until":        invoice.ValidUntil,
+			"payment_terms_type": invoice.PaymentTerms.Type,
+			"payment_terms_days": invoice.PaymentTerms.Days,
+			"notes":              invoice.Notes,
+			"footer_text":        invoice.FooterText,
+			"language":           invoice.Language,
+		}).Error; err != nil {
+			return err
+		}
+		return syncPayments(tx, stored.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	var updated models.Invoice
+	if err := db.Preload("Items").Preload("TaxLines").Preload("Payments").First(&updated, stored.ID).Error; err != nil {
+		return nil, err
+	}
+	updated.MarkOverdue(time.Now())
+	return &updated, nil
+}
+
+// replaceTaxLines swaps the stored tax breakdown of an invoice for the given lines.
+func replaceTaxLines(tx *gorm.DB, invoiceID uint, lines []models.InvoiceTaxLine) error {
+	if err := tx.Unscoped().Where("invoice_id = ?", invoiceID).Delete(&models.InvoiceTaxLine{}).Error; err != nil {
+		return err
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	for i := range lines {
+		lines[i].ID = 0
+		lines[i].InvoiceID = invoiceID
+	}
+	return tx.Create(&lines).Error
+}
+
+// whereOverdue restricts an invoice query to open invoices whose due date is before today.
+// It mirrors models.Invoice.IsOpen; ISO dates compare correctly as strings.
+func whereOverdue(q *gorm.DB, today time.Time) *gorm.DB {
+	return q.Where("document_type = ? AND status NOT IN ? AND total - amount_credited > amount_paid AND due_date <> '' AND due_date < ?",
+		models.DocumentTypeInvoice,
+		[]string{models.StatusDraft, models.StatusPaid, models.StatusVoid},
+		today.Format(time.DateOnly))
+}
+
+// markOverdue fills the derived overdue fields of loaded invoices.
+func markOverdue(invoices []models.Invoice) {
+	today := time.Now()
+	for i := range invoices {
+		invoices[i].MarkOverdue(today)
+	}
+}
+
+// DeleteInvoice deletes a draft invoice with its items, tax breakdown, payments and attachments in a transaction.
+// Time entries billed on it become billable again. Documents that have left Draft cannot be deleted
+// (*models.InvoiceLockedError); they are voided or credited instead.
 func (s *DatabaseService) DeleteInvoice(databasePath string, invoiceID uint) error {
 	d, err := appdb.Open(databasePath)
 	if err != nil {
@@ -507,9 +860,28 @@ func (s *DatabaseService) DeleteInvoice(databasePath string, invoiceID uint) err
 	defer d.Close()
 
 	return d.DB.Transaction(func(tx *gorm.DB) error {
+		var inv models.Invoice
+		if err := tx.First(&inv, invoiceID).Error; err != nil {
+			return err
+		}
+		if inv.Status != "" && inv.Status != models.StatusDraft {
+			return &models.InvoiceLockedError{Status: inv.Status}
+		}
 		if err := tx.Where("invoice_id = ?", invoiceID).Delete(&models.InvoiceItem{}).Error; err != nil {
 			return err
 		}
+		if err := tx.Where("invoice_id = ?", invoiceID).Delete(&models.InvoiceTaxLine{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("invoice_id = ?", invoiceID).Delete(&models.Payment{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("invoice_id = ?", invoiceID).Delete(&models.Attachment{}).Error; err != nil {
+			return err
+		}
+		if err := releaseTimeEntries(tx, []uint{invoiceID}); err != nil {
+			return err
+		}
 		if err := tx.Where("id = ?", invoiceID).Delete(&models.Invoice{}).Error; err != nil {
 			return err
 		}
@@ -517,7 +889,7 @@ func (s *DatabaseService) DeleteInvoice(databasePath string, invoiceID uint) err
 	})
 }
 
-// ListFiscalYears returns the distinct list of fiscal years present in invoices for a company (descending).
+// ListFiscalYears returns the distinct list of fiscal years present in invoices and expenses for a company (descending).
 func (s *DatabaseService) ListFiscalYears(databasePath string, companyID uint) ([]int, error) {
 	d, err := appdb.Open(databasePath)
 	if err != nil {
@@ -528,11 +900,24 @@ func (s *DatabaseService) ListFiscalYears(databasePath string, companyID uint) (
 	var years []int
 	if err := d.DB.Model(&models.Invoice{}).
 		Distinct().
-		Where("company_id = ? AND fiscal_year > 0", companyID).
+		Where("company_id = ? AND fiscal_year > 0 AND document_type <> ?", companyID, models.DocumentTypeQuote).
 		Order("fiscal_year DESC").
 		Pluck("fiscal_year", &years).Error; err != nil {
 		return nil, err
 	}
+	var expenseYears []int
+	if err := d.DB.Model(&models.Expense{}).
+		Distinct().
+		Where("company_id = ? AND fiscal_year > 0", companyID).
+		Pluck("fiscal_year", &expenseYears).Error; err != nil {
+		return nil, err
+	}
+	for _, y := range expenseYears {
+		if !slices.Contains(years, y) {
+			years = append(years, y)
+		}
+	}
+	slices.SortFunc(years, func(a, b int) int { return b - a })
 	return years, nil
 }
 
@@ -574,6 +959,12 @@ func (s *DatabaseService) UpdateCompanyDefaults(databasePath string, def models.
 	if def.CompanyID == 0 {
 		return nil, gorm.ErrMissingWhereClause
 	}
+	if err := def.DefaultPaymentTerms.Validate(); err != nil {
+		return nil, err
+	}
+	if err := checkBankAccount(d.DB, def.CompanyID, def.DefaultBankAccountID); err != nil {
+		return nil, err
+	}
 
 	var existing models.CompanyDefaults
 	err = d.DB.Where("company_id = ?", def.CompanyID).First(&existing).Error
@@ -589,16 +980,20 @@ func (s *DatabaseService) UpdateCompanyDefaults(databasePath string, def models.
 
 	existing.DefaultCurrency = def.DefaultCurrency
 	existing.DefaultTaxRate = def.DefaultTaxRate
+	existing.DefaultWithholdingRate = def.DefaultWithholdingRate
 	existing.DefaultFooterText = def.DefaultFooterText
+	existing.DefaultPaymentTerms = def.DefaultPaymentTerms
+	existing.DefaultBankAccountID = def.DefaultBankAccountID
 	if err := d.DB.Save(&existing).Error; err != nil {
 		return nil, err
 	}
 	return &existing, nil
 }
 
-// GetMaxInvoiceNumber returns the largest numeric invoice number for a company.
-// It considers only invoice numbers that are purely numeric (e.g., "1", "42").
-// If no numeric invoice numbers exist, it returns 0.
+// GetMaxInvoiceNumber returns the largest manually assigned invoice number for a company.
+// Deleted invoices are included so a number is never handed out twice; series-allocated
+// numbers and credit notes have their own sequences and are ignored (see PreviewNextNumber).
+// If no such invoice numbers exist, it returns 0.
 func (s *DatabaseService) GetMaxInvoiceNumber(databasePath string, companyID uint) (int, error) {
 	d, err := appdb.Open(databasePath)
 	if err != nil {
@@ -607,7 +1002,7 @@ func (s *DatabaseService) GetMaxInvoiceNumber(databasePath string, companyID uin
 	defer d.Close()
 
 	var inv models.Invoice
-	if err := d.DB.Where("company_id = ?", companyID).Order("number DESC").First(&inv).Error; err != nil {
+	if err := d.DB.Unscoped().Where("company_id = ? AND document_type = ? AND series_id IS NULL", companyID, models.DocumentTypeInvoice).Order("number DESC").First(&inv).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return 0, nil
 		}
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	appdb "github.com/fossinvoice/fossinvoice/internal/db"
+	"github.com/fossinvoice/fossinvoice/internal/models"
+	"gorm.io/gorm"
+)
+
+// ==============================
+// Invoice attachments
+// ==============================
+
+var (
+	// ErrDuplicateAttachment is returned when the same file content is already attached to the invoice.
+	ErrDuplicateAttachment = errors.New("file already attached to this invoice")
+	// ErrAttachmentChecksum is returned when stored attachment content no longer matches its SHA-256 digest.
+	ErrAttachmentChecksum = errors.New("attachment content does not match its checksum")
+)
+
+// ListInvoiceAttachments returns the attachments of an invoice in the order they were added, without their content.
+func (s *DatabaseService) ListInvoiceAttachments(databasePath string, invoiceID uint) ([]models.Attachment, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	var attachments []models.Attachment
+	if err := d.DB.Omit("data").Where("invoice_id = ?", invoiceID).Order("id ASC").Find(&attachments).Error; err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+// AddInvoiceAttachment stores the file at filePath with an invoice. Files can be attached to invoices
+// in any status. Files larger than 20 MB are rejected with ErrFileTooLarge.
+func (s *DatabaseService) AddInvoiceAttachment(databasePath string, invoiceID uint, filePath string) (*models.Attachment, error) {
+	data, mimeType, err := readStoredFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	attachment := models.Attachment{
+		InvoiceID: invoiceID,
+		FileName:  filepath.Base(filePath),
+		MimeType:  mimeType,
+		Size:      int64(len(data)),
+		SHA256:    hex.EncodeToString(sum[:]),
+		Data:      data,
+	}
+	err = d.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&models.Invoice{}, invoiceID).Error; err != nil {
+			return err
+		}
+		var existing int64
+		if err := tx.Model(&models.Attachment{}).Where("invoice_id = ? AND sha256 = ?", invoiceID, attachment.SHA256).
+			Count(&existing).Error; err != nil {
+			return err
+		}
+		if existing > 0 {
+			return ErrDuplicateAttachment
+		}
+		return tx.Create(&attachment).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	attachment.Data = nil
+	return &attachment, nil
+}
+
+// ExtractAttachment writes an attachment to outPath, creating parent directories if necessary. The
+// content is checked against its SHA-256 digest first.
+func (s *DatabaseService) ExtractAttachment(databasePath string, attachmentID uint, outPath string) error {
+	if strings.TrimSpace(outPath) == "" {
+		return gorm.ErrInvalidData
+	}
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	var a models.Attachment
+	if err := d.DB.First(&a, attachmentID).Error; err != nil {
+		return err
+	}
+	if err := verifyAttachment(&a); err != nil {
+		return err
+	}
+	if err := ensureDir(filepath.Dir(outPath)); err != nil {
+		return err
+	}
+	return os.WriteFile(outPath, a.Data, 0o644)
+}
+
+// DeleteAttachment deletes an attachment permanently, along with its content.
+func (s *DatabaseService) DeleteAttachment(databasePath string, attachmentID uint) error {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	return d.DB.Unscoped().Where("id = ?", attachmentID).Delete(&models.Attachment{}).Error
+}
+
+// invoiceAttachments loads the attachments of an invoice with their content, checking each digest.
+func invoiceAttachments(db *gorm.DB, invoiceID uint) ([]models.Attachment, error) {
+	var attachments []models.Attachment
+	if err := db.Where("invoice_id = ?", invoiceID).Order("id ASC").Find(&attachments).Error; err != nil {
+		return nil, err
+	}
+	for i := range attachments {
+		if err := verifyAttachment(&attachments[i]); err != nil {
+			return nil, err
+		}
+	}
+	return attachments, nil
+}
+
+// verifyAttachment returns ErrAttachmentChecksum when the content does not match the stored digest.
+func verifyAttachment(a *models.Attachment) error {
+	sum := sha256.Sum256(a.Data)
+	want, err := hex.DecodeString(a.SHA256)
+	if err != nil || !bytes.Equal(sum[:], want) {
+		return ErrAttachmentChecksum
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	appdb "github.com/fossinvoice/fossinvoice/internal/db"
+	"github.com/fossinvoice/fossinvoice/internal/models"
+	"gorm.io/gorm"
+)
+
+// ==============================
+// Bank accounts
+// ==============================
+
+// ListBankAccounts returns the bank accounts of a company.
+func (s *DatabaseService) ListBankAccounts(databasePath string, companyID uint) ([]models.BankAccount, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	var accounts []models.BankAccount
+	if err := d.DB.Where("company_id = ?", companyID).Order("name").Find(&accounts).Error; err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+// CreateBankAccount inserts a bank account for a company. IBAN and BIC are validated when given.
+func (s *DatabaseService) CreateBankAccount(databasePath string, companyID uint, account models.BankAccount) (*models.BankAccount, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	account.ID = 0
+	account.CompanyID = companyID
+	account.Normalize()
+	if err := account.Validate(); err != nil {
+		return nil, err
+	}
+	if err := d.DB.Create(&account).Error; err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// UpdateBankAccount updates a bank account (ID must be set). Invoices printed afterwards show the new
+// details, issued ones included.
+func (s *DatabaseService) UpdateBankAccount(databasePath string, account models.BankAccount) (*models.BankAccount, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	if account.ID == 0 {
+		return nil, gorm.ErrMissingWhereClause
+	}
+	account.Normalize()
+	if err := account.Validate(); err != nil {
+		return nil, err
+	}
+
+	var existing models.BankAccount
+	if err := d.DB.First(&existing, account.ID).Error; err != nil {
+		return nil, err
+	}
+	existing.Name = account.Name
+	existing.AccountHolder = account.AccountHolder
+	existing.BankName = account.BankName
+	existing.IBAN = account.IBAN
+	existing.BIC = account.BIC
+	existing.Details = account.Details
+	if err := d.DB.Save(&existing).Error; err != nil {
+		return nil, err
+	}
+	return &existing, nil
+}
+
+// DeleteBankAccount deletes a bank account. It stops being the company default and documents that can
+// still be edited stop using it; issued invoices keep printing it.
+func (s *DatabaseService) DeleteBankAccount(databasePath string, accountID uint) error {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	return d.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.CompanyDefaults{}).Where("default_bank_account_id = ?", accountID).
+			Update("default_bank_account_id", nil).Error; err != nil {
+			return err
+		}
+		if err := whereEditable(tx.Model(&models.Invoice{})).Where("bank_account_id = ?", accountID).
+			Update("bank_account_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", accountID).Delete(&models.BankAccount{}).Error
+	})
+}
+
+// checkBankAccount returns gorm.ErrInvalidData unless accountID is nil or a bank account of the company.
+func checkBankAccount(tx *gorm.DB, companyID uint, accountID *uint) error {
+	if accountID == nil {
+		return nil
+	}
+	var account models.BankAccount
+	if err := tx.Unscoped().First(&account, *accountID).Error; err != nil {
+		return err
+	}
+	if account.CompanyID != companyID {
+		return gorm.ErrInvalidData
+	}
+	return nil
+}
+
+// invoiceBankAccount returns the bank account printed on an invoice, or nil when it has none.
+// Deleted accounts are still returned so issued invoices reprint unchanged.
+func invoiceBankAccount(db *gorm.DB, inv *models.Invoice) (*models.BankAccount, error) {
+	if inv.BankAccountID == nil || inv.IsQuote() || inv.IsCreditNote() {
+		return nil, nil
+	}
+	var account models.BankAccount
+	if err := db.Unscoped().First(&account, *inv.BankAccountID).Error; err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
@@ -0,0 +1,214 @@
+package services
+
+import (
+	"errors"
+	"strings"
+
+	appdb "github.com/fossinvoice/fossinvoice/internal/db"
+	"github.com/fossinvoice/fossinvoice/internal/models"
+	"gorm.io/gorm"
+)
+
+// ==============================
+// Product & service catalog
+// ==============================
+
+// ErrDuplicateCatalogCode is returned when a catalog code is already used by another entry of the company.
+var ErrDuplicateCatalogCode = errors.New("catalog code already in use")
+
+// CatalogItemsPage represents a paginated result of catalog entries.
+type CatalogItemsPage struct {
+	Items []models.CatalogItem `json:"items"`
+	Total int64                `json:"total"`
+}
+
+// SearchCatalogItemsPaged returns the catalog entries of a company whose code, name or description
+// contains query (case-insensitive; empty matches all), ordered by name, with a total count for pagination.
+// Inactive entries are included only when includeInactive is set.
+func (s *DatabaseService) SearchCatalogItemsPaged(databasePath string, companyID uint, query string, includeInactive bool, limit, offset int) (*CatalogItemsPage, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	base := d.DB.Model(&models.CatalogItem{}).Where("company_id = ?", companyID)
+	if !includeInactive {
+		base = base.Where("active = ?", true)
+	}
+	if q := strings.TrimSpace(query); q != "" {
+		like := "%" + strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(strings.ToLower(q)) + "%"
+		base = base.Where(`(LOWER(code) LIKE ? ESCAPE '\' OR LOWER(name) LIKE ? ESCAPE '\' OR LOWER(description) LIKE ? ESCAPE '\')`, like, like, like)
+	}
+
+	var total int64
+	if err := base.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	var items []models.CatalogItem
+	q := base.Order("name ASC, code ASC")
+	if limit > 0 {
+		q = q.Limit(limit).Offset(offset)
+	}
+	if err := q.Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return &CatalogItemsPage{Items: items, Total: total}, nil
+}
+
+// GetCatalogItem returns a single catalog entry.
+func (s *DatabaseService) GetCatalogItem(databasePath string, catalogItemID uint) (*models.CatalogItem, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	var item models.CatalogItem
+	if err := d.DB.First(&item, catalogItemID).Error; err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// CreateCatalogItem inserts a catalog entry for a company.
+func (s *DatabaseService) CreateCatalogItem(databasePath string, companyID uint, item models.CatalogItem) (*models.CatalogItem, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	item.ID = 0
+	item.CompanyID = companyID
+	if err := validateCatalogItem(d.DB, &item); err != nil {
+		return nil, err
+	}
+	if err := d.DB.Create(&item).Error; err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// UpdateCatalogItem updates a catalog entry by primary key (ID must be set). Invoice lines created
+// from it keep the values they were created with.
+func (s *DatabaseService) UpdateCatalogItem(databasePath string, item models.CatalogItem) (*models.CatalogItem, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	if item.ID == 0 {
+		return nil, gorm.ErrMissingWhereClause
+	}
+	var stored models.CatalogItem
+	if err := d.DB.First(&stored, item.ID).Error; err != nil {
+		return nil, err
+	}
+	item.CompanyID = stored.CompanyID
+	item.CreatedAt = stored.CreatedAt
+	if err := validateCatalogItem(d.DB, &item); err != nil {
+		return nil, err
+	}
+	if err := d.DB.Save(&item).Error; err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// DeleteCatalogItem deletes a catalog entry. Invoice lines created from it keep their snapshot.
+func (s *DatabaseService) DeleteCatalogItem(databasePath string, catalogItemID uint) error {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	return d.DB.Where("id = ?", catalogItemID).Delete(&models.CatalogItem{}).Error
+}
+
+// NewInvoiceItemFromCatalog returns an unsaved invoice line for quantity units of a catalog entry,
+// holding a snapshot of its code, description, price and tax rate.
+func (s *DatabaseService) NewInvoiceItemFromCatalog(databasePath string, catalogItemID uint, quantity models.Decimal) (*models.InvoiceItem, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	var item models.CatalogItem
+	if err := d.DB.First(&item, catalogItemID).Error; err != nil {
+		return nil, err
+	}
+	it := item.NewInvoiceItem(quantity)
+	return &it, nil
+}
+
+// validateCatalogItem checks the name, tax rate and code uniqueness of a catalog entry.
+func validateCatalogItem(db *gorm.DB, item *models.CatalogItem) error {
+	item.Code = strings.TrimSpace(item.Code)
+	item.Name = strings.TrimSpace(item.Name)
+	if item.Name == "" {
+		return gorm.ErrInvalidData
+	}
+	if item.DefaultTaxRate != nil && (*item.DefaultTaxRate < 0 || *item.DefaultTaxRate > models.NewDecimal(100)) {
+		return models.ErrInvalidTaxRate
+	}
+	if !models.IsValidUnit(item.Unit) {
+		return models.ErrInvalidUnit
+	}
+	if item.Code == "" {
+		return nil
+	}
+	var count int64
+	if err := db.Model(&models.CatalogItem{}).
+		Where("company_id = ? AND code = ? AND id <> ?", item.CompanyID, item.Code, item.ID).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrDuplicateCatalogCode
+	}
+	return nil
+}
+
+// checkItemUnits returns models.ErrInvalidUnit if an item has an unknown unit of measure.
+func checkItemUnits(items []models.InvoiceItem) error {
+	for _, it := range items {
+		if !models.IsValidUnit(it.Unit) {
+			return models.ErrInvalidUnit
+		}
+	}
+	return nil
+}
+
+// checkCatalogRefs returns gorm.ErrInvalidData if an item references a catalog entry of another company.
+// Deleted entries are accepted, since the line keeps its own snapshot.
+func checkCatalogRefs(tx *gorm.DB, companyID uint, items []models.InvoiceItem) error {
+	ids := make([]uint, 0, len(items))
+	for _, it := range items {
+		if it.CatalogItemID != nil {
+			ids = append(ids, *it.CatalogItemID)
+		}
+	}
+	return checkCatalogIDs(tx, companyID, ids)
+}
+
+// checkCatalogIDs returns gorm.ErrInvalidData if one of the catalog entries belongs to another company.
+func checkCatalogIDs(tx *gorm.DB, companyID uint, ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	var foreign int64
+	if err := tx.Unscoped().Model(&models.CatalogItem{}).
+		Where("id IN ? AND company_id <> ?", ids, companyID).
+		Count(&foreign).Error; err != nil {
+		return err
+	}
+	if foreign > 0 {
+		return gorm.ErrInvalidData
+	}
+	return nil
+}
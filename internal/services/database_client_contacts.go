@@ -0,0 +1,290 @@
+package services
+
+import (
+	appdb "github.com/fossinvoice/fossinvoice/internal/db"
+	"github.com/fossinvoice/fossinvoice/internal/models"
+	"gorm.io/gorm"
+)
+
+// ==============================
+// Client contacts & addresses
+// ==============================
+
+// ListClientContacts returns the additional contacts of a client, defaults first within each role.
+func (s *DatabaseService) ListClientContacts(databasePath string, clientID uint) ([]models.ClientContact, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	var contacts []models.ClientContact
+	if err := d.DB.Where("client_id = ?", clientID).Order("role, is_default DESC, name").Find(&contacts).Error; err != nil {
+		return nil, err
+	}
+	return contacts, nil
+}
+
+// CreateClientContact adds a contact to a client. If it is marked as default, any other default
+// contact of the client with the same role loses the flag.
+func (s *DatabaseService) CreateClientContact(databasePath string, clientID uint, contact models.ClientContact) (*models.ClientContact, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	contact.ID = 0
+	contact.ClientID = clientID
+	if err := contact.Validate(); err != nil {
+		return nil, err
+	}
+	err = d.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&models.Client{}, clientID).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&contact).Error; err != nil {
+			return err
+		}
+		return clearOtherDefault(tx, &models.ClientContact{}, contact.ClientID, contact.Role, contact.ID, contact.IsDefault)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &contact, nil
+}
+
+// UpdateClientContact updates role, name, contact details and default flag of a contact (ID must be set).
+func (s *DatabaseService) UpdateClientContact(databasePath string, contact models.ClientContact) (*models.ClientContact, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	if contact.ID == 0 {
+		return nil, gorm.ErrMissingWhereClause
+	}
+	if err := contact.Validate(); err != nil {
+		return nil, err
+	}
+
+	var existing models.ClientContact
+	err = d.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&existing, contact.ID).Error; err != nil {
+			return err
+		}
+		existing.Role = contact.Role
+		existing.Name = contact.Name
+		existing.Contact = contact.Contact
+		existing.IsDefault = contact.IsDefault
+		if err := tx.Save(&existing).Error; err != nil {
+			return err
+		}
+		return clearOtherDefault(tx, &models.ClientContact{}, existing.ClientID, existing.Role, existing.ID, existing.IsDefault)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &existing, nil
+}
+
+// DeleteClientContact deletes a contact. Documents that can still be edited stop using it;
+// issued ones keep printing it.
+func (s *DatabaseService) DeleteClientContact(databasePath string, contactID uint) error {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	return d.DB.Transaction(func(tx *gorm.DB) error {
+		if err := whereEditable(tx.Model(&models.Invoice{})).Where("billing_contact_id = ?", contactID).
+			Update("billing_contact_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", contactID).Delete(&models.ClientContact{}).Error
+	})
+}
+
+// ListClientAddresses returns the additional addresses of a client, defaults first within each role.
+func (s *DatabaseService) ListClientAddresses(databasePath string, clientID uint) ([]models.ClientAddress, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	var addresses []models.ClientAddress
+	if err := d.DB.Where("client_id = ?", clientID).Order("role, is_default DESC, label").Find(&addresses).Error; err != nil {
+		return nil, err
+	}
+	return addresses, nil
+}
+
+// CreateClientAddress adds an address to a client. If it is marked as default, any other default
+// address of the client with the same role loses the flag.
+func (s *DatabaseService) CreateClientAddress(databasePath string, clientID uint, address models.ClientAddress) (*models.ClientAddress, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	address.ID = 0
+	address.ClientID = clientID
+	if err := address.Validate(); err != nil {
+		return nil, err
+	}
+	err = d.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&models.Client{}, clientID).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&address).Error; err != nil {
+			return err
+		}
+		return clearOtherDefault(tx, &models.ClientAddress{}, address.ClientID, address.Role, address.ID, address.IsDefault)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &address, nil
+}
+
+// UpdateClientAddress updates role, label, address and default flag of an address (ID must be set).
+// Documents using the address print the updated values, so when a client moves add a new address
+// and make it the default instead.
+func (s *DatabaseService) UpdateClientAddress(databasePath string, address models.ClientAddress) (*models.ClientAddress, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	if address.ID == 0 {
+		return nil, gorm.ErrMissingWhereClause
+	}
+	if err := address.Validate(); err != nil {
+		return nil, err
+	}
+
+	var existing models.ClientAddress
+	err = d.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&existing, address.ID).Error; err != nil {
+			return err
+		}
+		existing.Role = address.Role
+		existing.Label = address.Label
+		existing.Address = address.Address
+		existing.IsDefault = address.IsDefault
+		if err := tx.Save(&existing).Error; err != nil {
+			return err
+		}
+		return clearOtherDefault(tx, &models.ClientAddress{}, existing.ClientID, existing.Role, existing.ID, existing.IsDefault)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &existing, nil
+}
+
+// DeleteClientAddress deletes an address. Documents that can still be edited stop using it;
+// issued ones keep printing it.
+func (s *DatabaseService) DeleteClientAddress(databasePath string, addressID uint) error {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	return d.DB.Transaction(func(tx *gorm.DB) error {
+		if err := whereEditable(tx.Model(&models.Invoice{})).Where("billing_address_id = ?", addressID).
+			Update("billing_address_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", addressID).Delete(&models.ClientAddress{}).Error
+	})
+}
+
+// clearOtherDefault removes the default flag from the other contacts or addresses (model) of a client with the same role.
+func clearOtherDefault(tx *gorm.DB, model any, clientID uint, role string, keepID uint, isDefault bool) error {
+	if !isDefault {
+		return nil
+	}
+	return tx.Model(model).
+		Where("client_id = ? AND role = ? AND id <> ?", clientID, role, keepID).
+		Update("is_default", false).Error
+}
+
+// whereEditable restricts a document query to those whose financial fields can still change
+// (see models.Invoice.IsLocked).
+func whereEditable(q *gorm.DB) *gorm.DB {
+	return q.Where("(document_type = ? AND status NOT IN ?) OR (document_type <> ? AND status IN ?)",
+		models.DocumentTypeQuote, []string{models.StatusAccepted, models.StatusRejected},
+		models.DocumentTypeQuote, []string{"", models.StatusDraft})
+}
+
+// resolveBillingRefs checks that the billing contact and address of a document belong to its client
+// and, when they are not set, picks the client's default billing contact and address (if any) so the
+// document keeps printing them even if the defaults change later.
+func resolveBillingRefs(tx *gorm.DB, inv *models.Invoice) error {
+	if inv.BillingContactID != nil {
+		var contact models.ClientContact
+		if err := tx.Unscoped().First(&contact, *inv.BillingContactID).Error; err != nil {
+			return err
+		}
+		if contact.ClientID != inv.ClientID {
+			return gorm.ErrInvalidData
+		}
+	} else {
+		var contact models.ClientContact
+		err := tx.Where("client_id = ? AND role = ? AND is_default = ?", inv.ClientID, models.ContactRoleBilling, true).First(&contact).Error
+		switch {
+		case err == nil:
+			inv.BillingContactID = &contact.ID
+		case err != gorm.ErrRecordNotFound:
+			return err
+		}
+	}
+
+	if inv.BillingAddressID != nil {
+		var address models.ClientAddress
+		if err := tx.Unscoped().First(&address, *inv.BillingAddressID).Error; err != nil {
+			return err
+		}
+		if address.ClientID != inv.ClientID {
+			return gorm.ErrInvalidData
+		}
+	} else {
+		var address models.ClientAddress
+		err := tx.Where("client_id = ? AND role = ? AND is_default = ?", inv.ClientID, models.AddressRoleBilling, true).First(&address).Error
+		switch {
+		case err == nil:
+			inv.BillingAddressID = &address.ID
+		case err != gorm.ErrRecordNotFound:
+			return err
+		}
+	}
+	return nil
+}
+
+// billingDetails returns the address and contact a document is addressed to: the picked ones, or the
+// client's own address and no extra contact. Deleted records are still returned so issued documents reprint unchanged.
+func billingDetails(db *gorm.DB, inv *models.Invoice) (models.PostalAddress, *models.ClientContact, error) {
+	address := inv.Client.Address
+	if inv.BillingAddressID != nil {
+		var a models.ClientAddress
+		if err := db.Unscoped().First(&a, *inv.BillingAddressID).Error; err != nil {
+			return address, nil, err
+		}
+		address = a.Address
+	}
+	if inv.BillingContactID == nil {
+		return address, nil, nil
+	}
+	var contact models.ClientContact
+	if err := db.Unscoped().First(&contact, *inv.BillingContactID).Error; err != nil {
+		return address, nil, err
+	}
+	return address, &contact, nil
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"strings"
+
+	appdb "github.com/fossinvoice/fossinvoice/internal/db"
+	"github.com/fossinvoice/fossinvoice/internal/models"
+	"gorm.io/gorm"
+)
+
+// ==============================
+// Client Defaults CRUD
+// ==============================
+
+// GetClientDefaults returns the defaults of a client. Clients without their own defaults get an
+// empty, unsaved record in which every field inherits the company value.
+func (s *DatabaseService) GetClientDefaults(databasePath string, clientID uint) (*models.ClientDefaults, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	var def models.ClientDefaults
+	err = d.DB.Where("client_id = ?", clientID).First(&def).Error
+	if err == gorm.ErrRecordNotFound {
+		return &models.ClientDefaults{ClientID: clientID}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &def, nil
+}
+
+// UpdateClientDefaults upserts the defaults of a client (ClientID must be set).
+func (s *DatabaseService) UpdateClientDefaults(databasePath string, def models.ClientDefaults) (*models.ClientDefaults, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	if def.ClientID == 0 {
+		return nil, gorm.ErrMissingWhereClause
+	}
+	if err := def.Validate(); err != nil {
+		return nil, err
+	}
+	def.Currency = strings.ToUpper(strings.TrimSpace(def.Currency))
+	def.Language = strings.TrimSpace(def.Language)
+
+	var existing models.ClientDefaults
+	err = d.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&models.Client{}, def.ClientID).Error; err != nil {
+			return err
+		}
+		err := tx.Where("client_id = ?", def.ClientID).First(&existing).Error
+		if err == gorm.ErrRecordNotFound {
+			def.ID = 0
+			existing = def
+			return tx.Create(&existing).Error
+		}
+		if err != nil {
+			return err
+		}
+		existing.Currency = def.Currency
+		existing.TaxRate = def.TaxRate
+		existing.WithholdingRate = def.WithholdingRate
+		existing.PaymentTerms = def.PaymentTerms
+		existing.Language = def.Language
+		existing.FooterText = def.FooterText
+		return tx.Save(&existing).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &existing, nil
+}
+
+// DeleteClientDefaults removes the defaults of a client so it inherits the company defaults again.
+func (s *DatabaseService) DeleteClientDefaults(databasePath string, clientID uint) error {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	return d.DB.Where("client_id = ?", clientID).Delete(&models.ClientDefaults{}).Error
+}
+
+// GetInvoiceDefaults returns the values a new invoice starts with: the company defaults with the
+// client's defaults merged on top. With clientID 0 only the company defaults are used.
+func (s *DatabaseService) GetInvoiceDefaults(databasePath string, companyID, clientID uint) (*models.InvoiceDefaults, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	def, err := invoiceDefaults(d.DB, companyID, clientID)
+	if err != nil {
+		return nil, err
+	}
+	return &def, nil
+}
+
+// invoiceDefaults loads and merges the company and client defaults; missing records are treated as empty.
+func invoiceDefaults(tx *gorm.DB, companyID, clientID uint) (models.InvoiceDefaults, error) {
+	var company *models.CompanyDefaults
+	var cd models.CompanyDefaults
+	err := tx.Where("company_id = ?", companyID).First(&cd).Error
+	switch {
+	case err == nil:
+		company = &cd
+	case err != gorm.ErrRecordNotFound:
+		return models.InvoiceDefaults{}, err
+	}
+
+	var client *models.ClientDefaults
+	if clientID != 0 {
+		var cl models.ClientDefaults
+		err := tx.Where("client_id = ?", clientID).First(&cl).Error
+		switch {
+		case err == nil:
+			client = &cl
+		case err != gorm.ErrRecordNotFound:
+			return models.InvoiceDefaults{}, err
+		}
+	}
+	return models.MergeDefaults(company, client), nil
+}
+
+// applyInvoiceDefaults fills the blank Currency, Language and PaymentTerms (and the bank account of
+// invoices) of a new document from the merged company and client defaults, then computes its due
+// date from the terms. Rates are left as given, since 0 is a valid rate; callers prefill them with
+// GetInvoiceDefaults.
+func applyInvoiceDefaults(tx *gorm.DB, inv *models.Invoice) error {
+	if inv.Currency == "" || inv.Language == "" || inv.PaymentTerms.IsZero() || (inv.BankAccountID == nil && !inv.IsQuote()) {
+		def, err := invoiceDefaults(tx, inv.CompanyID, inv.ClientID)
+		if err != nil {
+			return err
+		}
+		if inv.Currency == "" {
+			inv.Currency = def.Currency
+		}
+		if inv.Language == "" {
+			inv.Language = def.Language
+		}
+		if inv.PaymentTerms.IsZero() {
+			inv.PaymentTerms = def.PaymentTerms
+		}
+		if inv.BankAccountID == nil && !inv.IsQuote() {
+			inv.BankAccountID = def.BankAccountID
+		}
+	}
+	if err := inv.PaymentTerms.Validate(); err != nil {
+		return err
+	}
+	if err := checkBankAccount(tx, inv.CompanyID, inv.BankAccountID); err != nil {
+		return err
+	}
+	computeDueDate(nil, inv)
+	return nil
+}
+
+// computeDueDate sets the due date of an invoice from its payment terms when it is new (stored is nil)
+// or its issue date or terms changed. Otherwise, or without terms, the due date is left as given so it
+// can still be adjusted by hand. Quotes have no due date.
+func computeDueDate(stored, inv *models.Invoice) {
+	if inv.IsQuote() || inv.PaymentTerms.IsZero() {
+		return
+	}
+	if stored != nil && stored.IssueDate == inv.IssueDate && stored.PaymentTerms == inv.PaymentTerms {
+		return
+	}
+	if due := inv.PaymentTerms.DueDate(inv.IssueDate); due != "" {
+		inv.DueDate = due
+	}
+}
@@ -0,0 +1,52 @@
+package services
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/fossinvoice/fossinvoice/internal/models"
+)
+
+func TestCreateInvoiceKeepsZeroRates(t *testing.T) {
+	s := &DatabaseService{}
+	path := filepath.Join(t.TempDir(), "invoices.db")
+
+	company, err := s.CreateCompany(path, models.Company{Name: "Acme"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := s.CreateClient(path, company.ID, models.Client{Name: "Client"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.UpdateCompanyDefaults(path, models.CompanyDefaults{
+		CompanyID:              company.ID,
+		DefaultCurrency:        "EUR",
+		DefaultTaxRate:         models.NewDecimal(21),
+		DefaultWithholdingRate: models.NewDecimal(15),
+		DefaultFooterText:      "Thank you",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	inv, err := s.CreateInvoice(path, models.Invoice{
+		CompanyID: company.ID,
+		ClientID:  client.ID,
+		IssueDate: "2025-03-01",
+		Items: []models.InvoiceItem{
+			{Description: "Exempt service", Quantity: models.NewDecimal(1), UnitPrice: models.NewDecimal(100)},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inv.TaxRate != 0 || inv.WithholdingRate != 0 || inv.FooterText != "" {
+		t.Errorf("rates %s/%s and footer %q, want 0/0 and empty", inv.TaxRate, inv.WithholdingRate, inv.FooterText)
+	}
+	if inv.Total != models.NewDecimal(100) {
+		t.Errorf("total = %s, want 100", inv.Total)
+	}
+	if inv.Currency != "EUR" {
+		t.Errorf("currency = %q, want the default EUR", inv.Currency)
+	}
+}
@@ -0,0 +1,187 @@
+package services
+
+import (
+	"errors"
+
+	appdb "github.com/fossinvoice/fossinvoice/internal/db"
+	"github.com/fossinvoice/fossinvoice/internal/models"
+	"gorm.io/gorm"
+)
+
+// ==============================
+// Credit notes
+// ==============================
+
+// ErrCreditExceedsBalance is returned when a credit note would credit more than what is left of the invoice total.
+var ErrCreditExceedsBalance = errors.New("credit note exceeds the remaining amount of the invoice")
+
+// CreateCreditNote issues a credit note against an existing invoice.
+// Company, client, currency and rates are copied from the original. If creditNote has no items, every
+// item of the original is credited (full cancellation); otherwise only the given items are (partial refund).
+// Quantities are stored negative. Unless Number or SeriesID is given, the credit note is numbered from the
+// company's default credit note series, or sequentially after the previous credit note if none is configured;
+// a Draft credit note is only numbered when it is issued.
+func (s *DatabaseService) CreateCreditNote(databasePath string, originalInvoiceID uint, creditNote models.Invoice) (*models.Invoice, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	err = d.DB.Transaction(func(tx *gorm.DB) error {
+		var orig models.Invoice
+		if err := tx.Preload("Items").First(&orig, originalInvoiceID).Error; err != nil {
+			return err
+		}
+		// Drafts are edited, not credited, and void invoices have nothing left to credit
+		if orig.IsCreditNote() || orig.IsQuote() || orig.Status == models.StatusDraft || orig.Status == models.StatusVoid {
+			return gorm.ErrInvalidData
+		}
+
+		origID := orig.ID
+		creditNote.ID = 0
+		creditNote.DocumentType = models.DocumentTypeCreditNote
+		creditNote.OriginalInvoiceID = &origID
+		creditNote.CompanyID = orig.CompanyID
+		creditNote.ClientID = orig.ClientID
+		creditNote.BillingContactID = orig.BillingContactID
+		creditNote.BillingAddressID = orig.BillingAddressID
+		creditNote.Currency = orig.Currency
+		creditNote.BaseCurrency = orig.BaseCurrency
+		creditNote.ExchangeRate = orig.ExchangeRate
+		// Kept when the credit note is edited, like a rate entered by hand
+		creditNote.ExchangeRateManual = orig.ExchangeRate > 0
+		creditNote.TaxRate = orig.TaxRate
+		creditNote.WithholdingRate = orig.WithholdingRate
+		creditNote.AmountPaid, creditNote.AmountCredited = 0, 0
+		creditNote.Payments = nil
+		if creditNote.FiscalYear == 0 {
+			creditNote.FiscalYear = orig.FiscalYear
+		}
+		if err := creditNote.CheckInitialStatus(); err != nil {
+			return err
+		}
+		if len(creditNote.Items) == 0 {
+			creditNote.DiscountRate = orig.DiscountRate
+			creditNote.DiscountAmount = orig.DiscountAmount
+			for _, it := range orig.Items {
+				it.Model = gorm.Model{}
+				it.InvoiceID = 0
+				creditNote.Items = append(creditNote.Items, it)
+			}
+		}
+		for i := range creditNote.Items {
+			creditNote.Items[i].ID = 0
+		}
+		if err := creditNote.ComputeTotals(); err != nil {
+			return err
+		}
+
+		if err := checkCreditLimit(tx, &orig, &creditNote); err != nil {
+			return err
+		}
+
+		if err := numberNewDocument(tx, &creditNote); err != nil {
+			return err
+		}
+
+		items, taxLines := creditNote.Items, creditNote.TaxLines
+		creditNote.Items, creditNote.TaxLines = nil, nil
+		if err := tx.Create(&creditNote).Error; err != nil {
+			return err
+		}
+		for i := range items {
+			items[i].InvoiceID = creditNote.ID
+		}
+		if len(items) > 0 {
+			if err := tx.Create(&items).Error; err != nil {
+				return err
+			}
+		}
+		if err := replaceTaxLines(tx, creditNote.ID, taxLines); err != nil {
+			return err
+		}
+		creditNote.Items, creditNote.TaxLines = items, taxLines
+		return syncPayments(tx, orig.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &creditNote, nil
+}
+
+// ListCreditNotes returns the credit notes issued against an invoice.
+func (s *DatabaseService) ListCreditNotes(databasePath string, invoiceID uint) ([]models.Invoice, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	var notes []models.Invoice
+	if err := d.DB.Where("original_invoice_id = ? AND document_type = ?", invoiceID, models.DocumentTypeCreditNote).
+		Order("number ASC").Find(&notes).Error; err != nil {
+		return nil, err
+	}
+	return notes, nil
+}
+
+// GetInvoiceBalance returns the outstanding balance of an invoice: its total reduced by its credit notes and payments.
+func (s *DatabaseService) GetInvoiceBalance(databasePath string, invoiceID uint) (models.Decimal, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return 0, err
+	}
+	defer d.Close()
+
+	var inv models.Invoice
+	if err := d.DB.First(&inv, invoiceID).Error; err != nil {
+		return 0, err
+	}
+	return invoiceBalance(d.DB, &inv)
+}
+
+// checkCreditLimit returns ErrCreditExceedsBalance when creditNote credits more than what is left of
+// orig, or anything at all of a void invoice. creditNote itself must not be issued yet.
+func checkCreditLimit(tx *gorm.DB, orig, creditNote *models.Invoice) error {
+	if orig.Status == models.StatusVoid {
+		return ErrCreditExceedsBalance
+	}
+	creditable, err := creditableAmount(tx, orig)
+	if err != nil {
+		return err
+	}
+	if creditNote.Total.Neg() > creditable {
+		return ErrCreditExceedsBalance
+	}
+	return nil
+}
+
+// creditableAmount returns the invoice total reduced by the credit notes already issued against it.
+// Draft and void credit notes do not count.
+func creditableAmount(tx *gorm.DB, inv *models.Invoice) (models.Decimal, error) {
+	if inv.IsCreditNote() {
+		return 0, nil
+	}
+	var credited int64
+	if err := tx.Model(&models.Invoice{}).
+		Where("original_invoice_id = ? AND document_type = ? AND status NOT IN ?", inv.ID, models.DocumentTypeCreditNote,
+			[]string{models.StatusDraft, models.StatusVoid}).
+		Select("COALESCE(SUM(total), 0)").Scan(&credited).Error; err != nil {
+		return 0, err
+	}
+	return inv.Total.Add(models.Decimal(credited)), nil
+}
+
+// invoiceBalance computes the outstanding balance of inv (credit notes and AmountPaid deducted)
+// using the given DB handle or transaction.
+func invoiceBalance(tx *gorm.DB, inv *models.Invoice) (models.Decimal, error) {
+	if inv.IsCreditNote() {
+		return 0, nil
+	}
+	creditable, err := creditableAmount(tx, inv)
+	if err != nil {
+		return 0, err
+	}
+	return creditable.Sub(inv.AmountPaid), nil
+}
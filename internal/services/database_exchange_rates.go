@@ -0,0 +1,392 @@
+package services
+
+import (
+	"errors"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	appdb "github.com/fossinvoice/fossinvoice/internal/db"
+	"github.com/fossinvoice/fossinvoice/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ==============================
+// Exchange rates & base-currency reporting
+// ==============================
+
+// ErrNoBaseCurrency is returned by base-currency reports when the company has no default currency.
+var ErrNoBaseCurrency = errors.New("company has no default currency")
+
+// ExchangeRatesPage represents a paginated result of exchange rates.
+type ExchangeRatesPage struct {
+	Items []models.ExchangeRate `json:"items"`
+	Total int64                 `json:"total"`
+}
+
+// ListExchangeRates returns stored exchange rates, newest first, optionally filtered by base currency
+// and currency (empty matches all), with a total count for pagination.
+func (s *DatabaseService) ListExchangeRates(databasePath string, baseCurrency, currency string, limit, offset int) (*ExchangeRatesPage, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	base := d.DB.Model(&models.ExchangeRate{})
+	if baseCurrency != "" {
+		base = base.Where("base_currency = ?", strings.ToUpper(baseCurrency))
+	}
+	if currency != "" {
+		base = base.Where("currency = ?", strings.ToUpper(currency))
+	}
+
+	var total int64
+	if err := base.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	var items []models.ExchangeRate
+	q := base.Order("date DESC, base_currency ASC, currency ASC")
+	if limit > 0 {
+		q = q.Limit(limit).Offset(offset)
+	}
+	if err := q.Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return &ExchangeRatesPage{Items: items, Total: total}, nil
+}
+
+// SetExchangeRate stores a manually entered rate, replacing any rate of the same day and currency pair.
+func (s *DatabaseService) SetExchangeRate(databasePath string, rate models.ExchangeRate) (*models.ExchangeRate, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	rate.Source = models.RateSourceManual
+	if err := upsertExchangeRates(d.DB, []models.ExchangeRate{rate}); err != nil {
+		return nil, err
+	}
+	var stored models.ExchangeRate
+	if err := d.DB.Where("date = ? AND base_currency = ? AND currency = ?",
+		rate.Date, strings.ToUpper(rate.BaseCurrency), strings.ToUpper(rate.Currency)).First(&stored).Error; err != nil {
+		return nil, err
+	}
+	return &stored, nil
+}
+
+// DeleteExchangeRate deletes a stored rate. Invoices keep the rate snapshot they were issued with.
+func (s *DatabaseService) DeleteExchangeRate(databasePath string, rateID uint) error {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	return d.DB.Unscoped().Where("id = ?", rateID).Delete(&models.ExchangeRate{}).Error
+}
+
+// ImportExchangeRates reads an ECB reference rates file (the eurofxref daily or historical
+// download, as XML or CSV) and stores its rates with EUR as base currency, replacing existing
+// rates of the same days. It returns the number of rates imported.
+func (s *DatabaseService) ImportExchangeRates(databasePath string, filePath string) (int, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return 0, err
+	}
+	rates, err := parseECBRates(data)
+	if err != nil {
+		return 0, err
+	}
+
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return 0, err
+	}
+	defer d.Close()
+
+	if err := upsertExchangeRates(d.DB, rates); err != nil {
+		return 0, err
+	}
+	return len(rates), nil
+}
+
+// CurrencyTotal is the part of a base-currency report issued in one currency.
+type CurrencyTotal struct {
+	Currency     string         `json:"currency"`
+	Count        int            `json:"count"`
+	Total        models.Decimal `json:"total"`        // in Currency
+	BaseTotal    models.Decimal `json:"baseTotal"`    // converted, invoices without a rate excluded
+	MissingRates int            `json:"missingRates"` // invoices that could not be converted
+}
+
+// BaseCurrencyReport sums a company's issued invoices and credit notes in its base currency, and
+// its expenses to report profit and the tax balance.
+type BaseCurrencyReport struct {
+	BaseCurrency      string          `json:"baseCurrency"`
+	FiscalYear        int             `json:"fiscalYear"`
+	Count             int             `json:"count"`
+	Subtotal          models.Decimal  `json:"subtotal"` // net of invoice discounts
+	TaxAmount         models.Decimal  `json:"taxAmount"`
+	WithholdingAmount models.Decimal  `json:"withholdingAmount"`
+	Total             models.Decimal  `json:"total"`
+	AmountPaid        models.Decimal  `json:"amountPaid"`
+	MissingRates      int             `json:"missingRates"` // documents left out because no rate was known
+	ByCurrency        []CurrencyTotal `json:"byCurrency"`
+	// Expenses of the same period, converted at the rate of their date
+	ExpenseCount        int            `json:"expenseCount"`
+	ExpenseNet          models.Decimal `json:"expenseNet"`
+	ExpenseTax          models.Decimal `json:"expenseTax"`
+	ExpenseTotal        models.Decimal `json:"expenseTotal"`
+	ExpenseMissingRates int            `json:"expenseMissingRates"` // expenses left out because no rate was known
+	Profit              models.Decimal `json:"profit"`              // Subtotal - ExpenseNet
+	TaxBalance          models.Decimal `json:"taxBalance"`          // TaxAmount - ExpenseTax: tax collected minus tax paid
+}
+
+// GetBaseCurrencyReport returns the totals of a company's issued documents (drafts, void invoices and
+// quotes excluded) and expenses converted to its default currency. If fiscalYear > 0, only that year
+// is included. Each document is converted with the rate snapshot it was issued with; documents issued
+// under another base currency, or before rates were recorded, and expenses are converted at the stored
+// rate of their date.
+func (s *DatabaseService) GetBaseCurrencyReport(databasePath string, companyID uint, fiscalYear int) (*BaseCurrencyReport, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	base, err := companyBaseCurrency(d.DB, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if base == "" {
+		return nil, ErrNoBaseCurrency
+	}
+
+	var invoices []models.Invoice
+	q := d.DB.Where("company_id = ? AND document_type <> ? AND status NOT IN ?", companyID, models.DocumentTypeQuote,
+		[]string{models.StatusDraft, models.StatusVoid})
+	if fiscalYear > 0 {
+		q = q.Where("fiscal_year = ?", fiscalYear)
+	}
+	if err := q.Order("issue_date ASC").Find(&invoices).Error; err != nil {
+		return nil, err
+	}
+
+	report := &BaseCurrencyReport{BaseCurrency: base, FiscalYear: fiscalYear}
+	byCurrency := map[string]*CurrencyTotal{}
+	for i := range invoices {
+		inv := &invoices[i]
+		ct := byCurrency[inv.Currency]
+		if ct == nil {
+			ct = &CurrencyTotal{Currency: inv.Currency}
+			byCurrency[inv.Currency] = ct
+		}
+		ct.Count++
+		ct.Total = ct.Total.Add(inv.Total)
+
+		toBase := inv.ToBase
+		if inv.BaseCurrency != base {
+			q, err := lookupExchangeRate(d.DB, base, inv.Currency, inv.IssueDate)
+			if err != nil {
+				return nil, err
+			}
+			toBase = q.toBase
+		}
+		total, ok := toBase(inv.Total)
+		if !ok {
+			ct.MissingRates++
+			report.MissingRates++
+			continue
+		}
+		subtotal, _ := toBase(inv.Subtotal.Sub(inv.DiscountAmount))
+		tax, _ := toBase(inv.TaxAmount)
+		withholding, _ := toBase(inv.WithholdingAmount)
+		paid, _ := toBase(inv.AmountPaid)
+		ct.BaseTotal = ct.BaseTotal.Add(total)
+		report.Count++
+		report.Subtotal = report.Subtotal.Add(subtotal)
+		report.TaxAmount = report.TaxAmount.Add(tax)
+		report.WithholdingAmount = report.WithholdingAmount.Add(withholding)
+		report.Total = report.Total.Add(total)
+		report.AmountPaid = report.AmountPaid.Add(paid)
+	}
+	for _, ct := range byCurrency {
+		report.ByCurrency = append(report.ByCurrency, *ct)
+	}
+	sort.Slice(report.ByCurrency, func(i, j int) bool { return report.ByCurrency[i].Currency < report.ByCurrency[j].Currency })
+
+	var expenses []models.Expense
+	eq := d.DB.Omit("receipt").Where("company_id = ?", companyID)
+	if fiscalYear > 0 {
+		eq = eq.Where("fiscal_year = ?", fiscalYear)
+	}
+	if err := eq.Order("date ASC").Find(&expenses).Error; err != nil {
+		return nil, err
+	}
+	for _, e := range expenses {
+		q, err := lookupExchangeRate(d.DB, base, e.Currency, e.Date)
+		if err != nil {
+			return nil, err
+		}
+		if !q.known() {
+			report.ExpenseMissingRates++
+			continue
+		}
+		net, _ := q.toBase(e.Net)
+		tax, _ := q.toBase(e.Tax)
+		total, _ := q.toBase(e.Total)
+		report.ExpenseCount++
+		report.ExpenseNet = report.ExpenseNet.Add(net)
+		report.ExpenseTax = report.ExpenseTax.Add(tax)
+		report.ExpenseTotal = report.ExpenseTotal.Add(total)
+	}
+	report.Profit = report.Subtotal.Sub(report.ExpenseNet)
+	report.TaxBalance = report.TaxAmount.Sub(report.ExpenseTax)
+	return report, nil
+}
+
+// companyBaseCurrency returns the default currency of a company, or "" if none is configured.
+func companyBaseCurrency(db *gorm.DB, companyID uint) (string, error) {
+	var def models.CompanyDefaults
+	err := db.Where("company_id = ?", companyID).First(&def).Error
+	if err == gorm.ErrRecordNotFound {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.ToUpper(strings.TrimSpace(def.DefaultCurrency)), nil
+}
+
+// upsertExchangeRates validates and stores rates, replacing those of the same day and currency pair.
+func upsertExchangeRates(db *gorm.DB, rates []models.ExchangeRate) error {
+	for i := range rates {
+		r := &rates[i]
+		r.ID = 0
+		r.BaseCurrency = strings.ToUpper(strings.TrimSpace(r.BaseCurrency))
+		r.Currency = strings.ToUpper(strings.TrimSpace(r.Currency))
+		if _, err := time.Parse(time.DateOnly, r.Date); err != nil {
+			return models.ErrInvalidExchangeRate
+		}
+		if len(r.BaseCurrency) != 3 || len(r.Currency) != 3 || r.BaseCurrency == r.Currency || r.Rate <= 0 {
+			return models.ErrInvalidExchangeRate
+		}
+	}
+	if len(rates) == 0 {
+		return nil
+	}
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "date"}, {Name: "base_currency"}, {Name: "currency"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rate", "source", "updated_at", "deleted_at"}),
+	}).CreateInBatches(&rates, 500).Error
+}
+
+// exchangeQuote states that units of a currency are worth per units of the base currency. Inverse and
+// cross quotes keep both stored rates so conversions round only once.
+type exchangeQuote struct {
+	base       string
+	units, per models.Rate
+}
+
+// known reports whether a rate was found.
+func (q exchangeQuote) known() bool { return q.units > 0 && q.per > 0 }
+
+// rate returns the units of currency per unit of base, rounded to models.RatePlaces, or 0 if unknown.
+func (q exchangeQuote) rate() models.Rate {
+	if !q.known() {
+		return 0
+	}
+	return q.units.Div(q.per)
+}
+
+// toBase converts an amount in the currency to the base currency, rounded to the base currency.
+// ok is false when no rate is known.
+func (q exchangeQuote) toBase(amount models.Decimal) (converted models.Decimal, ok bool) {
+	if !q.known() {
+		return 0, false
+	}
+	return amount.Convert(q.per, q.units).RoundCurrency(q.base), true
+}
+
+// lookupExchangeRate returns the quote of currency against base on the given ISO date (today when
+// empty), using the latest stored rate on or before it. Besides a direct quote, the inverse quote and
+// a cross rate through a common base (e.g. EUR for ECB rates) are used. The quote is unknown if no
+// rate is stored.
+func lookupExchangeRate(db *gorm.DB, base, currency, date string) (exchangeQuote, error) {
+	q := exchangeQuote{base: base}
+	if base == currency {
+		q.units, q.per = models.NewRate(1), models.NewRate(1)
+		return q, nil
+	}
+	if date == "" {
+		date = time.Now().Format(time.DateOnly)
+	}
+	latest := func(from, to string) (models.Rate, error) {
+		var r models.ExchangeRate
+		err := db.Where("base_currency = ? AND currency = ? AND date <= ?", from, to, date).Order("date DESC").First(&r).Error
+		if err == gorm.ErrRecordNotFound {
+			return 0, nil
+		}
+		return r.Rate, err
+	}
+
+	// 1 base = r currency
+	if r, err := latest(base, currency); err != nil || r > 0 {
+		q.units, q.per = r, models.NewRate(1)
+		return q, err
+	}
+	// 1 currency = r base
+	if r, err := latest(currency, base); err != nil || r > 0 {
+		q.units, q.per = models.NewRate(1), r
+		return q, err
+	}
+	var pivots []string
+	if err := db.Model(&models.ExchangeRate{}).Distinct().Pluck("base_currency", &pivots).Error; err != nil {
+		return q, err
+	}
+	for _, p := range pivots {
+		toBase, err := latest(p, base)
+		if err != nil {
+			return q, err
+		}
+		toCurrency, err := latest(p, currency)
+		if err != nil {
+			return q, err
+		}
+		// 1 pivot = toCurrency currency = toBase base
+		if toBase > 0 && toCurrency > 0 {
+			q.units, q.per = toCurrency, toBase
+			return q, nil
+		}
+	}
+	return q, nil
+}
+
+// snapshotExchangeRate records the company base currency and the rate of the invoice currency on its
+// issue date. A rate entered by hand (ExchangeRateManual) for the same base currency is kept; any
+// other snapshot is refreshed from the stored rates.
+func snapshotExchangeRate(tx *gorm.DB, inv *models.Invoice) error {
+	base, err := companyBaseCurrency(tx, inv.CompanyID)
+	if err != nil {
+		return err
+	}
+	if base == "" {
+		base = inv.Currency
+	}
+	if inv.ExchangeRateManual && inv.ExchangeRate > 0 && inv.Currency != base &&
+		(inv.BaseCurrency == "" || inv.BaseCurrency == base) {
+		inv.BaseCurrency = base
+		return nil
+	}
+	q, err := lookupExchangeRate(tx, base, inv.Currency, inv.IssueDate)
+	if err != nil {
+		return err
+	}
+	inv.BaseCurrency, inv.ExchangeRate, inv.ExchangeRateManual = base, q.rate(), false
+	return nil
+}
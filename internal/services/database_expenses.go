@@ -0,0 +1,228 @@
+package services
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	appdb "github.com/fossinvoice/fossinvoice/internal/db"
+	"github.com/fossinvoice/fossinvoice/internal/models"
+	"gorm.io/gorm"
+)
+
+// ==============================
+// Expenses
+// ==============================
+
+// ErrNoReceipt is returned when exporting the receipt of an expense that has none.
+var ErrNoReceipt = errors.New("expense has no receipt")
+
+// ExpensesPage represents a paginated result of expenses.
+type ExpensesPage struct {
+	Items []models.Expense `json:"items"`
+	Total int64            `json:"total"`
+}
+
+// ListExpensesPaged returns the expenses of a company, newest first, with a total count for pagination.
+// If fiscalYear > 0, filters by FiscalYear. If category is not empty, only that category is returned.
+func (s *DatabaseService) ListExpensesPaged(databasePath string, companyID uint, fiscalYear int, category string, limit, offset int) (*ExpensesPage, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	base := d.DB.Model(&models.Expense{}).Where("company_id = ?", companyID)
+	if fiscalYear > 0 {
+		base = base.Where("fiscal_year = ?", fiscalYear)
+	}
+	if c := strings.TrimSpace(category); c != "" {
+		base = base.Where("category = ?", c)
+	}
+
+	var total int64
+	if err := base.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	var items []models.Expense
+	q := base.Omit("receipt").Order("date DESC, id DESC")
+	if limit > 0 {
+		q = q.Limit(limit).Offset(offset)
+	}
+	if err := q.Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return &ExpensesPage{Items: items, Total: total}, nil
+}
+
+// ListExpenseCategories returns the distinct categories used by a company's expenses, sorted by name.
+func (s *DatabaseService) ListExpenseCategories(databasePath string, companyID uint) ([]string, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	var categories []string
+	if err := d.DB.Model(&models.Expense{}).
+		Distinct().
+		Where("company_id = ? AND category <> ''", companyID).
+		Order("category ASC").
+		Pluck("category", &categories).Error; err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+// GetExpense returns a single expense, without the receipt content.
+func (s *DatabaseService) GetExpense(databasePath string, expenseID uint) (*models.Expense, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	var e models.Expense
+	if err := d.DB.Omit("receipt").First(&e, expenseID).Error; err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// CreateExpense inserts an expense for a company. Total is computed from Net and Tax; the receipt is
+// attached afterwards with SetExpenseReceipt.
+func (s *DatabaseService) CreateExpense(databasePath string, companyID uint, expense models.Expense) (*models.Expense, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	expense.ID = 0
+	expense.CompanyID = companyID
+	expense.ReceiptName, expense.ReceiptMimeType, expense.Receipt = "", "", nil
+	expense.Normalize()
+	if err := expense.Validate(); err != nil {
+		return nil, err
+	}
+	if err := d.DB.Create(&expense).Error; err != nil {
+		return nil, err
+	}
+	return &expense, nil
+}
+
+// UpdateExpense updates an expense (ID must be set). The receipt is kept.
+func (s *DatabaseService) UpdateExpense(databasePath string, expense models.Expense) (*models.Expense, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	if expense.ID == 0 {
+		return nil, gorm.ErrMissingWhereClause
+	}
+	expense.Normalize()
+	if err := expense.Validate(); err != nil {
+		return nil, err
+	}
+
+	var existing models.Expense
+	if err := d.DB.Omit("receipt").First(&existing, expense.ID).Error; err != nil {
+		return nil, err
+	}
+	existing.Date = expense.Date
+	existing.FiscalYear = expense.FiscalYear
+	existing.Supplier = expense.Supplier
+	existing.Category = expense.Category
+	existing.Description = expense.Description
+	existing.Net = expense.Net
+	existing.Tax = expense.Tax
+	existing.Total = expense.Total
+	existing.Currency = expense.Currency
+	if err := d.DB.Omit("receipt", "receipt_name", "receipt_mime_type").Save(&existing).Error; err != nil {
+		return nil, err
+	}
+	return &existing, nil
+}
+
+// DeleteExpense deletes an expense permanently, along with its receipt.
+func (s *DatabaseService) DeleteExpense(databasePath string, expenseID uint) error {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	return d.DB.Unscoped().Where("id = ?", expenseID).Delete(&models.Expense{}).Error
+}
+
+// SetExpenseReceipt stores the file at filePath as the receipt of an expense, replacing any previous one.
+// Files larger than 20 MB are rejected with ErrFileTooLarge.
+func (s *DatabaseService) SetExpenseReceipt(databasePath string, expenseID uint, filePath string) (*models.Expense, error) {
+	data, mimeType, err := readStoredFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	var e models.Expense
+	if err := d.DB.Omit("receipt").First(&e, expenseID).Error; err != nil {
+		return nil, err
+	}
+	if err := d.DB.Model(&e).Updates(map[string]any{
+		"receipt_name":      filepath.Base(filePath),
+		"receipt_mime_type": mimeType,
+		"receipt":           data,
+	}).Error; err != nil {
+		return nil, err
+	}
+	e.Receipt = nil
+	return &e, nil
+}
+
+// RemoveExpenseReceipt deletes the receipt of an expense.
+func (s *DatabaseService) RemoveExpenseReceipt(databasePath string, expenseID uint) error {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	return d.DB.Model(&models.Expense{}).Where("id = ?", expenseID).Updates(map[string]any{
+		"receipt_name":      "",
+		"receipt_mime_type": "",
+		"receipt":           nil,
+	}).Error
+}
+
+// ExportExpenseReceipt writes the receipt of an expense to outPath, creating parent directories if necessary.
+func (s *DatabaseService) ExportExpenseReceipt(databasePath string, expenseID uint, outPath string) error {
+	if strings.TrimSpace(outPath) == "" {
+		return gorm.ErrInvalidData
+	}
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	var e models.Expense
+	if err := d.DB.First(&e, expenseID).Error; err != nil {
+		return err
+	}
+	if e.ReceiptName == "" {
+		return ErrNoReceipt
+	}
+	if err := ensureDir(filepath.Dir(outPath)); err != nil {
+		return err
+	}
+	return os.WriteFile(outPath, e.Receipt, 0o644)
+}
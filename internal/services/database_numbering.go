@@ -0,0 +1,254 @@
+package services
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	appdb "github.com/fossinvoice/fossinvoice/internal/db"
+	"github.com/fossinvoice/fossinvoice/internal/models"
+	"gorm.io/gorm"
+)
+
+// ==============================
+// Numbering series
+// ==============================
+
+// ErrDuplicateNumber is returned when a document number is already used by another document
+// of the same company and type (including deleted ones, so numbers are never reused).
+var ErrDuplicateNumber = errors.New("document number already in use")
+
+// ListNumberingSeries returns the numbering series of a company.
+func (s *DatabaseService) ListNumberingSeries(databasePath string, companyID uint) ([]models.NumberingSeries, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	var series []models.NumberingSeries
+	if err := d.DB.Where("company_id = ?", companyID).Order("document_type, name").Find(&series).Error; err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+// CreateNumberingSeries inserts a new numbering series for a company.
+// If it is marked as default, any other default series of the same document type loses the flag.
+func (s *DatabaseService) CreateNumberingSeries(databasePath string, companyID uint, series models.NumberingSeries) (*models.NumberingSeries, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	series.CompanyID = companyID
+	if series.DocumentType == "" {
+		series.DocumentType = models.DocumentTypeInvoice
+	}
+	if err := series.Validate(); err != nil {
+		return nil, err
+	}
+	err = d.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&series).Error; err != nil {
+			return err
+		}
+		return clearOtherDefaultSeries(tx, &series)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+// UpdateNumberingSeries updates name, pattern, reset policy and default flag of a series (ID must be set).
+// Numbers already allocated are not affected.
+func (s *DatabaseService) UpdateNumberingSeries(databasePath string, series models.NumberingSeries) (*models.NumberingSeries, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	if series.ID == 0 {
+		return nil, gorm.ErrMissingWhereClause
+	}
+	if err := series.Validate(); err != nil {
+		return nil, err
+	}
+
+	var existing models.NumberingSeries
+	err = d.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&existing, series.ID).Error; err != nil {
+			return err
+		}
+		existing.Name = series.Name
+		existing.Pattern = series.Pattern
+		existing.ResetPolicy = series.ResetPolicy
+		existing.IsDefault = series.IsDefault
+		if err := tx.Save(&existing).Error; err != nil {
+			return err
+		}
+		return clearOtherDefaultSeries(tx, &existing)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &existing, nil
+}
+
+// DeleteNumberingSeries deletes a numbering series. Documents keep the numbers they were given.
+func (s *DatabaseService) DeleteNumberingSeries(databasePath string, seriesID uint) error {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	return d.DB.Where("id = ?", seriesID).Delete(&models.NumberingSeries{}).Error
+}
+
+// PreviewNextNumber returns the number the series would hand out next for a fiscal year, without reserving it.
+func (s *DatabaseService) PreviewNextNumber(databasePath string, seriesID uint, fiscalYear int) (string, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return "", err
+	}
+	defer d.Close()
+
+	var series models.NumberingSeries
+	if err := d.DB.First(&series, seriesID).Error; err != nil {
+		return "", err
+	}
+	var counter models.NumberingCounter
+	err = d.DB.Where("series_id = ? AND year = ?", series.ID, series.CounterYear(fiscalYear)).First(&counter).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return "", err
+	}
+	return series.Format(fiscalYear, counter.LastNumber+1), nil
+}
+
+// clearOtherDefaultSeries removes the default flag from the other series of the same company and document type.
+func clearOtherDefaultSeries(tx *gorm.DB, series *models.NumberingSeries) error {
+	if !series.IsDefault {
+		return nil
+	}
+	return tx.Model(&models.NumberingSeries{}).
+		Where("company_id = ? AND document_type = ? AND id <> ?", series.CompanyID, series.DocumentType, series.ID).
+		Update("is_default", false).Error
+}
+
+// numberingYear returns the fiscal year used for numbering: FiscalYear, else the issue date year, else the current year.
+func numberingYear(inv *models.Invoice) int {
+	if inv.FiscalYear > 0 {
+		return inv.FiscalYear
+	}
+	if len(inv.IssueDate) >= 4 {
+		if y, err := strconv.Atoi(inv.IssueDate[:4]); err == nil && y > 0 {
+			return y
+		}
+	}
+	return time.Now().Year()
+}
+
+// allocateNumber reserves the next number of a series inside tx. The counter is bumped with a single
+// UPDATE first, which takes SQLite's write lock, so concurrent allocations can never read the same value.
+func allocateNumber(tx *gorm.DB, series *models.NumberingSeries, fiscalYear int) (int, error) {
+	year := series.CounterYear(fiscalYear)
+	res := tx.Model(&models.NumberingCounter{}).
+		Where("series_id = ? AND year = ?", series.ID, year).
+		UpdateColumn("last_number", gorm.Expr("last_number + 1"))
+	if res.Error != nil {
+		return 0, res.Error
+	}
+	if res.RowsAffected == 0 {
+		counter := models.NumberingCounter{SeriesID: series.ID, Year: year, LastNumber: 1}
+		if err := tx.Create(&counter).Error; err != nil {
+			return 0, err
+		}
+		return counter.LastNumber, nil
+	}
+	var counter models.NumberingCounter
+	if err := tx.Where("series_id = ? AND year = ?", series.ID, year).First(&counter).Error; err != nil {
+		return 0, err
+	}
+	return counter.LastNumber, nil
+}
+
+// numberNewDocument assigns the number of a new document inside tx, unless it is a Draft without a
+// manual number: drafts are numbered by UpdateInvoice when they leave Draft, so deleting one never
+// leaves a gap and the number follows the fiscal year the document is finally issued in.
+func numberNewDocument(tx *gorm.DB, inv *models.Invoice) error {
+	if inv.Status == models.StatusDraft && (inv.SeriesID != nil || inv.Number <= 0) {
+		inv.Number, inv.DisplayNumber = 0, ""
+		return nil
+	}
+	return assignNumber(tx, inv)
+}
+
+// assignNumber sets Number, DisplayNumber and SeriesID of a document being issued inside tx.
+//
+//   - A positive Number without a series is a manual number and is kept as is.
+//   - Otherwise the number is allocated from the given series, or from the company's default
+//     series for the document type.
+//   - Without any series, the next plain number after the highest one ever used (deleted
+//     documents included) is taken.
+//
+// The resulting number is checked against every document of the company and type, deleted ones included.
+func assignNumber(tx *gorm.DB, inv *models.Invoice) error {
+	if inv.SeriesID == nil && inv.Number > 0 {
+		inv.DisplayNumber = strconv.Itoa(inv.Number)
+		return ensureNumberFree(tx, inv)
+	}
+
+	var series models.NumberingSeries
+	q := tx.Where("company_id = ? AND document_type = ?", inv.CompanyID, inv.DocumentType)
+	if inv.SeriesID != nil {
+		q = q.Where("id = ?", *inv.SeriesID)
+	} else {
+		q = q.Where("is_default = ?", true)
+	}
+	err := q.First(&series).Error
+	switch {
+	case err == nil:
+		year := numberingYear(inv)
+		n, err := allocateNumber(tx, &series, year)
+		if err != nil {
+			return err
+		}
+		seriesID := series.ID
+		inv.SeriesID = &seriesID
+		inv.Number = n
+		inv.DisplayNumber = series.Format(year, n)
+	case err == gorm.ErrRecordNotFound && inv.SeriesID == nil:
+		var maxNumber int
+		if err := tx.Unscoped().Model(&models.Invoice{}).
+			Where("company_id = ? AND document_type = ? AND series_id IS NULL", inv.CompanyID, inv.DocumentType).
+			Select("COALESCE(MAX(number), 0)").Scan(&maxNumber).Error; err != nil {
+			return err
+		}
+		inv.Number = maxNumber + 1
+		inv.DisplayNumber = strconv.Itoa(inv.Number)
+	default:
+		return err
+	}
+	return ensureNumberFree(tx, inv)
+}
+
+// ensureNumberFree returns ErrDuplicateNumber if another document (deleted ones included) already uses inv's number.
+func ensureNumberFree(tx *gorm.DB, inv *models.Invoice) error {
+	if strings.TrimSpace(inv.DisplayNumber) == "" {
+		return gorm.ErrInvalidData
+	}
+	var count int64
+	if err := tx.Unscoped().Model(&models.Invoice{}).
+		Where("company_id = ? AND document_type = ? AND display_number = ? AND id <> ?", inv.CompanyID, inv.DocumentType, inv.DisplayNumber, inv.ID).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrDuplicateNumber
+	}
+	return nil
+}
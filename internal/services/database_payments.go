@@ -0,0 +1,164 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	appdb "github.com/fossinvoice/fossinvoice/internal/db"
+	"github.com/fossinvoice/fossinvoice/internal/models"
+	"gorm.io/gorm"
+)
+
+// ==============================
+// Payments
+// ==============================
+
+var (
+	// ErrInvalidPayment is returned for payments with a non-positive amount or against a draft, a void invoice or a credit note.
+	ErrInvalidPayment = errors.New("invalid payment")
+	// ErrPaymentExceedsBalance is returned when a payment is larger than the outstanding balance.
+	ErrPaymentExceedsBalance = errors.New("payment exceeds the outstanding balance of the invoice")
+)
+
+// ListPayments returns the payments of an invoice (voided ones included), oldest first.
+func (s *DatabaseService) ListPayments(databasePath string, invoiceID uint) ([]models.Payment, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	var payments []models.Payment
+	if err := d.DB.Where("invoice_id = ?", invoiceID).Order("date ASC, id ASC").Find(&payments).Error; err != nil {
+		return nil, err
+	}
+	return payments, nil
+}
+
+// RecordPayment adds a payment to an invoice and updates its amount paid and status
+// (PartiallyPaid, or Paid once the outstanding balance reaches zero).
+func (s *DatabaseService) RecordPayment(databasePath string, invoiceID uint, payment models.Payment) (*models.Payment, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	err = d.DB.Transaction(func(tx *gorm.DB) error {
+		var inv models.Invoice
+		if err := tx.First(&inv, invoiceID).Error; err != nil {
+			return err
+		}
+		if inv.IsCreditNote() || inv.IsQuote() || !inv.IsLocked() || inv.Status == models.StatusVoid {
+			return ErrInvalidPayment
+		}
+		payment.Amount = payment.Amount.RoundCurrency(inv.Currency)
+		if payment.Amount <= 0 {
+			return ErrInvalidPayment
+		}
+		balance, err := invoiceBalance(tx, &inv)
+		if err != nil {
+			return err
+		}
+		if payment.Amount > balance {
+			return ErrPaymentExceedsBalance
+		}
+
+		payment.ID = 0
+		payment.InvoiceID = inv.ID
+		payment.VoidedAt = nil
+		if payment.Date == "" {
+			payment.Date = time.Now().Format(time.DateOnly)
+		}
+		if err := tx.Create(&payment).Error; err != nil {
+			return err
+		}
+		return syncPayments(tx, inv.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
+
+// VoidPayment marks a payment as voided and updates the invoice amount paid and status accordingly.
+func (s *DatabaseService) VoidPayment(databasePath string, paymentID uint) error {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	return d.DB.Transaction(func(tx *gorm.DB) error {
+		var payment models.Payment
+		if err := tx.First(&payment, paymentID).Error; err != nil {
+			return err
+		}
+		if payment.IsVoided() {
+			return nil
+		}
+		if err := tx.Model(&payment).Update("voided_at", time.Now()).Error; err != nil {
+			return err
+		}
+		return syncPayments(tx, payment.InvoiceID)
+	})
+}
+
+// syncPayments recomputes AmountPaid and AmountCredited of an invoice from its non-voided payments
+// and issued credit notes and derives the payment status: Paid when nothing is outstanding,
+// PartiallyPaid while payments cover part of it, and back to Sent when payments or credit notes are
+// voided. Invoices that never had a payment or credit note keep their status. Called for a credit
+// note, it settles the credited invoice.
+func syncPayments(tx *gorm.DB, invoiceID uint) error {
+	var inv models.Invoice
+	if err := tx.First(&inv, invoiceID).Error; err != nil {
+		return err
+	}
+	if inv.IsCreditNote() {
+		if inv.OriginalInvoiceID == nil {
+			return nil
+		}
+		return syncPayments(tx, *inv.OriginalInvoiceID)
+	}
+
+	var recorded, notes int64
+	if err := tx.Model(&models.Payment{}).Where("invoice_id = ?", inv.ID).Count(&recorded).Error; err != nil {
+		return err
+	}
+	if err := tx.Model(&models.Invoice{}).
+		Where("original_invoice_id = ? AND document_type = ? AND status <> ?", inv.ID, models.DocumentTypeCreditNote, models.StatusDraft).
+		Count(&notes).Error; err != nil {
+		return err
+	}
+	if recorded == 0 && notes == 0 {
+		return nil
+	}
+	var paid int64
+	if err := tx.Model(&models.Payment{}).
+		Where("invoice_id = ? AND voided_at IS NULL", inv.ID).
+		Select("COALESCE(SUM(amount), 0)").Scan(&paid).Error; err != nil {
+		return err
+	}
+	inv.AmountPaid = models.Decimal(paid)
+	creditable, err := creditableAmount(tx, &inv)
+	if err != nil {
+		return err
+	}
+	inv.AmountCredited = inv.Total.Sub(creditable)
+
+	status := inv.Status
+	switch {
+	case status == models.StatusVoid || status == models.StatusDraft:
+	case inv.AmountPaid.Add(inv.AmountCredited) > 0 && inv.OpenBalance() <= 0:
+		status = models.StatusPaid
+	case paid > 0:
+		status = models.StatusPartiallyPaid
+	case status == models.StatusPaid || status == models.StatusPartiallyPaid:
+		status = models.StatusSent
+	}
+	return tx.Model(&models.Invoice{}).Where("id = ?", inv.ID).Updates(map[string]any{
+		"amount_paid":     inv.AmountPaid,
+		"amount_credited": inv.AmountCredited,
+		"status":          status,
+	}).Error
+}
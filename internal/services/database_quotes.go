@@ -0,0 +1,144 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	appdb "github.com/fossinvoice/fossinvoice/internal/db"
+	"github.com/fossinvoice/fossinvoice/internal/models"
+	"gorm.io/gorm"
+)
+
+// ==============================
+// Quotes
+// ==============================
+
+// ErrQuoteAlreadyConverted is returned when a quote has already been turned into an invoice.
+var ErrQuoteAlreadyConverted = errors.New("quote has already been converted into an invoice")
+
+// CreateQuote inserts a new quote (estimate) with its items. Quotes are stored alongside invoices with
+// DocumentType Quote and numbered on their own: from SeriesID, the company's default quote series, or
+// sequentially after the previous quote. Totals are computed as for invoices.
+func (s *DatabaseService) CreateQuote(databasePath string, quote models.Invoice) (*models.Invoice, error) {
+	quote.DocumentType = models.DocumentTypeQuote
+	quote.OriginalInvoiceID = nil
+	quote.QuoteID = nil
+	quote.AmountPaid, quote.AmountCredited = 0, 0
+	quote.Payments = nil
+	if err := quote.CheckInitialStatus(); err != nil {
+		return nil, err
+	}
+
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	var client models.Client
+	if err := d.DB.First(&client, quote.ClientID).Error; err != nil {
+		return nil, err
+	}
+	if client.CompanyID != quote.CompanyID {
+		return nil, gorm.ErrInvalidData
+	}
+
+	err = d.DB.Transaction(func(tx *gorm.DB) error {
+		return insertInvoice(tx, &quote)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &quote, nil
+}
+
+// ListQuotes returns the quotes of a company with optional filters.
+// If fiscalYear > 0, filters by FiscalYear. If clientID > 0, filters by ClientID.
+func (s *DatabaseService) ListQuotes(databasePath string, companyID uint, fiscalYear int, clientID uint) ([]models.Invoice, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	var quotes []models.Invoice
+	q := d.DB.Where("company_id = ? AND document_type = ?", companyID, models.DocumentTypeQuote)
+	if fiscalYear > 0 {
+		q = q.Where("fiscal_year = ?", fiscalYear)
+	}
+	if clientID > 0 {
+		q = q.Where("client_id = ?", clientID)
+	}
+	if err := q.Order("created_at DESC").Find(&quotes).Error; err != nil {
+		return nil, err
+	}
+	return quotes, nil
+}
+
+// ConvertQuoteToInvoice creates a Draft invoice from a quote, copying its client, currency, rates,
+// discount, notes and items, and marks the quote Accepted. The invoice is dated today and numbered
+// like one created with CreateInvoice. Only quotes that were sent (or already accepted) can be converted,
+// and a quote converts only once.
+func (s *DatabaseService) ConvertQuoteToInvoice(databasePath string, quoteID uint) (*models.Invoice, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	var invoice models.Invoice
+	err = d.DB.Transaction(func(tx *gorm.DB) error {
+		var quote models.Invoice
+		if err := tx.Preload("Items").First(&quote, quoteID).Error; err != nil {
+			return err
+		}
+		if !quote.IsQuote() {
+			return gorm.ErrInvalidData
+		}
+		if quote.Status != models.StatusSent && quote.Status != models.StatusAccepted {
+			return &models.StatusTransitionError{From: quote.Status, To: models.StatusAccepted}
+		}
+		var converted int64
+		if err := tx.Model(&models.Invoice{}).Where("quote_id = ?", quote.ID).Count(&converted).Error; err != nil {
+			return err
+		}
+		if converted > 0 {
+			return ErrQuoteAlreadyConverted
+		}
+
+		now := time.Now()
+		quoteRef := quote.ID
+		invoice = models.Invoice{
+			CompanyID:        quote.CompanyID,
+			ClientID:         quote.ClientID,
+			BillingContactID: quote.BillingContactID,
+			BillingAddressID: quote.BillingAddressID,
+			DocumentType:     models.DocumentTypeInvoice,
+			QuoteID:          &quoteRef,
+			IssueDate:        now.Format(time.DateOnly),
+			FiscalYear:       now.Year(),
+			Currency:         quote.Currency,
+			PaymentTerms:     quote.PaymentTerms,
+			TaxRate:          quote.TaxRate,
+			DiscountRate:     quote.DiscountRate,
+			DiscountAmount:   quote.DiscountAmount,
+			WithholdingRate:  quote.WithholdingRate,
+			Status:           models.StatusDraft,
+			Notes:            quote.Notes,
+			FooterText:       quote.FooterText,
+		}
+		for _, it := range quote.Items {
+			it.Model = gorm.Model{}
+			it.InvoiceID = 0
+			invoice.Items = append(invoice.Items, it)
+		}
+		if err := insertInvoice(tx, &invoice); err != nil {
+			return err
+		}
+		return tx.Model(&models.Invoice{}).Where("id = ?", quote.ID).Update("status", models.StatusAccepted).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &invoice, nil
+}
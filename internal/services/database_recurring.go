@@ -0,0 +1,244 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	appdb "github.com/fossinvoice/fossinvoice/internal/db"
+	"github.com/fossinvoice/fossinvoice/internal/models"
+	"gorm.io/gorm"
+)
+
+// ==============================
+// Recurring invoices
+// ==============================
+
+// ListRecurringInvoices returns the recurring invoice schedules of a company with their items.
+func (s *DatabaseService) ListRecurringInvoices(databasePath string, companyID uint) ([]models.RecurringInvoice, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	var schedules []models.RecurringInvoice
+	if err := d.DB.Preload("Items").Where("company_id = ?", companyID).Order("next_run_date ASC, name ASC").Find(&schedules).Error; err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+// GetRecurringInvoice returns a single recurring invoice schedule with its items.
+func (s *DatabaseService) GetRecurringInvoice(databasePath string, recurringID uint) (*models.RecurringInvoice, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	var rec models.RecurringInvoice
+	if err := d.DB.Preload("Items").First(&rec, recurringID).Error; err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
+
+// CreateRecurringInvoice inserts a recurring invoice schedule (and its items) for a company.
+// NextRunDate defaults to StartDate, so the first invoice is issued on the start date.
+func (s *DatabaseService) CreateRecurringInvoice(databasePath string, companyID uint, rec models.RecurringInvoice) (*models.RecurringInvoice, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	rec.CompanyID = companyID
+	rec.LastRunDate = ""
+	if err := prepareRecurringInvoice(d.DB, &rec); err != nil {
+		return nil, err
+	}
+
+	err = d.DB.Transaction(func(tx *gorm.DB) error {
+		items := rec.Items
+		rec.Items = nil
+		if err := tx.Create(&rec).Error; err != nil {
+			return err
+		}
+		rec.Items = items
+		return replaceRecurringItems(tx, &rec)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
+
+// UpdateRecurringInvoice updates a schedule (ID must be set) and replaces its items.
+// Invoices already generated are not affected; LastRunDate is kept, and so is NextRunDate unless
+// a new one is given, so editing a schedule does not issue its past invoices again.
+func (s *DatabaseService) UpdateRecurringInvoice(databasePath string, rec models.RecurringInvoice) (*models.RecurringInvoice, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	if rec.ID == 0 {
+		return nil, gorm.ErrMissingWhereClause
+	}
+	var stored models.RecurringInvoice
+	if err := d.DB.First(&stored, rec.ID).Error; err != nil {
+		return nil, err
+	}
+	rec.CompanyID = stored.CompanyID
+	rec.LastRunDate = stored.LastRunDate
+	if rec.NextRunDate == "" {
+		rec.NextRunDate = stored.NextRunDate
+	}
+	rec.CreatedAt = stored.CreatedAt
+	if err := prepareRecurringInvoice(d.DB, &rec); err != nil {
+		return nil, err
+	}
+
+	err = d.DB.Transaction(func(tx *gorm.DB) error {
+		items := rec.Items
+		rec.Items = nil
+		if err := tx.Save(&rec).Error; err != nil {
+			return err
+		}
+		rec.Items = items
+		return replaceRecurringItems(tx, &rec)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
+
+// DeleteRecurringInvoice deletes a schedule and its items. Invoices it generated are kept.
+func (s *DatabaseService) DeleteRecurringInvoice(databasePath string, recurringID uint) error {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	return d.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("recurring_invoice_id = ?", recurringID).Delete(&models.RecurringInvoiceItem{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", recurringID).Delete(&models.RecurringInvoice{}).Error
+	})
+}
+
+// GenerateRecurringInvoices issues every invoice that fell due up to today for the active schedules
+// of a company (all companies when companyID is 0), catching up on every missed period. Each invoice
+// is numbered like one created with CreateInvoice, dated on its scheduled day, and the schedule's
+// NextRunDate is advanced. It returns the generated invoices.
+func (s *DatabaseService) GenerateRecurringInvoices(databasePath string, companyID uint) ([]models.Invoice, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	return generateRecurringInvoices(d.DB, companyID, time.Now())
+}
+
+// prepareRecurringInvoice validates a schedule and checks its client and catalog entries belong to its company.
+func prepareRecurringInvoice(db *gorm.DB, rec *models.RecurringInvoice) error {
+	if err := rec.Validate(); err != nil {
+		return err
+	}
+	if rec.Status == "" {
+		rec.Status = models.StatusDraft
+	}
+	if rec.Status != models.StatusDraft && rec.Status != models.StatusSent {
+		return &models.StatusTransitionError{To: rec.Status}
+	}
+	var catalogIDs []uint
+	for _, it := range rec.Items {
+		if !models.IsValidUnit(it.Unit) {
+			return models.ErrInvalidUnit
+		}
+		if it.CatalogItemID != nil {
+			catalogIDs = append(catalogIDs, *it.CatalogItemID)
+		}
+	}
+	var client models.Client
+	if err := db.First(&client, rec.ClientID).Error; err != nil {
+		return err
+	}
+	if client.CompanyID != rec.CompanyID {
+		return gorm.ErrInvalidData
+	}
+	return checkCatalogIDs(db, rec.CompanyID, catalogIDs)
+}
+
+// replaceRecurringItems swaps the stored items of a schedule for rec.Items.
+func replaceRecurringItems(tx *gorm.DB, rec *models.RecurringInvoice) error {
+	if err := tx.Unscoped().Where("recurring_invoice_id = ?", rec.ID).Delete(&models.RecurringInvoiceItem{}).Error; err != nil {
+		return err
+	}
+	if len(rec.Items) == 0 {
+		return nil
+	}
+	for i := range rec.Items {
+		rec.Items[i].Model = gorm.Model{}
+		rec.Items[i].RecurringInvoiceID = rec.ID
+	}
+	return tx.Create(&rec.Items).Error
+}
+
+// generateRecurringInvoices implements GenerateRecurringInvoices on an open database. Each schedule
+// is processed in its own transaction, so one failing schedule does not hold back the others;
+// the errors are joined and returned with the invoices that were generated.
+func generateRecurringInvoices(db *gorm.DB, companyID uint, today time.Time) ([]models.Invoice, error) {
+	q := db.Preload("Items").Where("active = ? AND next_run_date <= ?", true, today.Format(time.DateOnly))
+	if companyID > 0 {
+		q = q.Where("company_id = ?", companyID)
+	}
+	var schedules []models.RecurringInvoice
+	if err := q.Find(&schedules).Error; err != nil {
+		return nil, err
+	}
+
+	var generated []models.Invoice
+	var errs []error
+	for i := range schedules {
+		rec := &schedules[i]
+		var batch []models.Invoice
+		err := db.Transaction(func(tx *gorm.DB) error {
+			for rec.IsDue(today) {
+				inv, err := rec.NewInvoice(rec.NextRunDate)
+				if err != nil {
+					return err
+				}
+				if err := inv.CheckInitialStatus(); err != nil {
+					return err
+				}
+				if err := inv.ComputeTotals(); err != nil {
+					return err
+				}
+				if err := insertInvoice(tx, &inv); err != nil {
+					return err
+				}
+				batch = append(batch, inv)
+				rec.LastRunDate = rec.NextRunDate
+				if err := rec.Advance(); err != nil {
+					return err
+				}
+			}
+			return tx.Model(&models.RecurringInvoice{}).Where("id = ?", rec.ID).Updates(map[string]any{
+				"next_run_date": rec.NextRunDate,
+				"last_run_date": rec.LastRunDate,
+			}).Error
+		})
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		generated = append(generated, batch...)
+	}
+	return generated, errors.Join(errs...)
+}
@@ -0,0 +1,262 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	appdb "github.com/fossinvoice/fossinvoice/internal/db"
+	"github.com/fossinvoice/fossinvoice/internal/models"
+	"gorm.io/gorm"
+)
+
+// ==============================
+// Time tracking
+// ==============================
+
+var (
+	// ErrTimeEntryInvoiced is returned when changing or deleting a time entry that was already invoiced.
+	ErrTimeEntryInvoiced = errors.New("time entry already invoiced")
+	// ErrNoTimeEntries is returned when there is no unbilled time to invoice.
+	ErrNoTimeEntries = errors.New("no unbilled time entries")
+)
+
+// TimeEntriesPage represents a paginated result of time entries.
+type TimeEntriesPage struct {
+	Items []models.TimeEntry `json:"items"`
+	Total int64              `json:"total"`
+}
+
+// ListTimeEntriesPaged returns the time entries of a company, newest first, with a total count for
+// pagination. If clientID > 0, filters by ClientID. from and to are inclusive ISO dates; empty leaves
+// the range open. If unbilledOnly is set, only billable entries not invoiced yet are returned.
+func (s *DatabaseService) ListTimeEntriesPaged(databasePath string, companyID, clientID uint, from, to string, unbilledOnly bool, limit, offset int) (*TimeEntriesPage, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	base := whereTimeRange(d.DB.Model(&models.TimeEntry{}).Where("company_id = ?", companyID), from, to)
+	if clientID > 0 {
+		base = base.Where("client_id = ?", clientID)
+	}
+	if unbilledOnly {
+		base = whereUnbilled(base)
+	}
+
+	var total int64
+	if err := base.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	var items []models.TimeEntry
+	q := base.Order("date DESC, id DESC")
+	if limit > 0 {
+		q = q.Limit(limit).Offset(offset)
+	}
+	if err := q.Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return &TimeEntriesPage{Items: items, Total: total}, nil
+}
+
+// CreateTimeEntry inserts a time entry for a client of the company.
+func (s *DatabaseService) CreateTimeEntry(databasePath string, companyID uint, entry models.TimeEntry) (*models.TimeEntry, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	entry.ID = 0
+	entry.CompanyID = companyID
+	entry.Invoiced, entry.InvoiceID = false, nil
+	entry.Normalize()
+	if err := entry.Validate(); err != nil {
+		return nil, err
+	}
+	if err := checkClientOfCompany(d.DB, companyID, entry.ClientID); err != nil {
+		return nil, err
+	}
+	// Create skips zero values that have a column default, so an explicit false would become true
+	billable := entry.Billable
+	if err := d.DB.Create(&entry).Error; err != nil {
+		return nil, err
+	}
+	if !billable {
+		if err := d.DB.Model(&entry).Update("billable", false).Error; err != nil {
+			return nil, err
+		}
+	}
+	return &entry, nil
+}
+
+// UpdateTimeEntry updates a time entry (ID must be set). Invoiced entries cannot be changed.
+func (s *DatabaseService) UpdateTimeEntry(databasePath string, entry models.TimeEntry) (*models.TimeEntry, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	if entry.ID == 0 {
+		return nil, gorm.ErrMissingWhereClause
+	}
+	entry.Normalize()
+	if err := entry.Validate(); err != nil {
+		return nil, err
+	}
+
+	var existing models.TimeEntry
+	if err := d.DB.First(&existing, entry.ID).Error; err != nil {
+		return nil, err
+	}
+	if existing.Invoiced {
+		return nil, ErrTimeEntryInvoiced
+	}
+	if err := checkClientOfCompany(d.DB, existing.CompanyID, entry.ClientID); err != nil {
+		return nil, err
+	}
+	existing.ClientID = entry.ClientID
+	existing.Date = entry.Date
+	existing.Duration = entry.Duration
+	existing.Description = entry.Description
+	existing.Rate = entry.Rate
+	existing.Billable = entry.Billable
+	if err := d.DB.Save(&existing).Error; err != nil {
+		return nil, err
+	}
+	return &existing, nil
+}
+
+// DeleteTimeEntry deletes a time entry. Invoiced entries cannot be deleted.
+func (s *DatabaseService) DeleteTimeEntry(databasePath string, entryID uint) error {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	var entry models.TimeEntry
+	if err := d.DB.First(&entry, entryID).Error; err != nil {
+		return err
+	}
+	if entry.Invoiced {
+		return ErrTimeEntryInvoiced
+	}
+	return d.DB.Delete(&entry).Error
+}
+
+// InvoiceTimeEntries creates a Draft invoice for the unbilled time of a client between from and to
+// (inclusive ISO dates; empty leaves the range open) and marks the entries as invoiced. Entries with
+// the same description and rate are grouped into one line in hours, in order of their first date.
+// Currency, terms and rates come from the company and client defaults. Deleting the draft makes
+// the entries billable again.
+func (s *DatabaseService) InvoiceTimeEntries(databasePath string, companyID, clientID uint, from, to string) (*models.Invoice, error) {
+	d, err := appdb.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	var invoice models.Invoice
+	err = d.DB.Transaction(func(tx *gorm.DB) error {
+		if err := checkClientOfCompany(tx, companyID, clientID); err != nil {
+			return err
+		}
+		var entries []models.TimeEntry
+		q := whereUnbilled(whereTimeRange(tx.Where("company_id = ? AND client_id = ?", companyID, clientID), from, to))
+		if err := q.Order("date ASC, id ASC").Find(&entries).Error; err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			return ErrNoTimeEntries
+		}
+
+		def, err := invoiceDefaults(tx, companyID, clientID)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		invoice = models.Invoice{
+			CompanyID:       companyID,
+			ClientID:        clientID,
+			DocumentType:    models.DocumentTypeInvoice,
+			IssueDate:       now.Format(time.DateOnly),
+			FiscalYear:      now.Year(),
+			TaxRate:         def.TaxRate,
+			WithholdingRate: def.WithholdingRate,
+			Status:          models.StatusDraft,
+			Items:           timeEntryItems(entries),
+		}
+		if err := insertInvoice(tx, &invoice); err != nil {
+			return err
+		}
+
+		ids := make([]uint, len(entries))
+		for i, e := range entries {
+			ids[i] = e.ID
+		}
+		return tx.Model(&models.TimeEntry{}).Where("id IN ?", ids).
+			Updates(map[string]any{"invoiced": true, "invoice_id": invoice.ID}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &invoice, nil
+}
+
+// timeEntryItems groups time entries with the same description and rate into invoice lines in hours.
+func timeEntryItems(entries []models.TimeEntry) []models.InvoiceItem {
+	type key struct {
+		description string
+		rate        models.Decimal
+	}
+	var items []models.InvoiceItem
+	index := map[key]int{}
+	for _, e := range entries {
+		k := key{e.Description, e.Rate}
+		i, ok := index[k]
+		if !ok {
+			i = len(items)
+			index[k] = i
+			items = append(items, models.InvoiceItem{Description: e.Description, Unit: models.UnitHour, UnitPrice: e.Rate})
+		}
+		items[i].Quantity = items[i].Quantity.Add(e.Duration)
+	}
+	return items
+}
+
+// releaseTimeEntries makes the time entries billed on the given invoices billable again.
+func releaseTimeEntries(tx *gorm.DB, invoiceIDs any) error {
+	return tx.Model(&models.TimeEntry{}).Where("invoice_id IN (?)", invoiceIDs).
+		Updates(map[string]any{"invoiced": false, "invoice_id": nil}).Error
+}
+
+// checkClientOfCompany returns gorm.ErrInvalidData unless the client belongs to the company.
+func checkClientOfCompany(tx *gorm.DB, companyID, clientID uint) error {
+	var client models.Client
+	if err := tx.First(&client, clientID).Error; err != nil {
+		return err
+	}
+	if client.CompanyID != companyID {
+		return gorm.ErrInvalidData
+	}
+	return nil
+}
+
+// whereUnbilled restricts a time entry query to billable entries not invoiced yet.
+func whereUnbilled(q *gorm.DB) *gorm.DB {
+	return q.Where("billable = ? AND invoiced = ?", true, false)
+}
+
+// whereTimeRange restricts a time entry query to dates between from and to, inclusive; empty bounds are open.
+func whereTimeRange(q *gorm.DB, from, to string) *gorm.DB {
+	if from != "" {
+		q = q.Where("date >= ?", from)
+	}
+	if to != "" {
+		q = q.Where("date <= ?", to)
+	}
+	return q
+}
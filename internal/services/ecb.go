@@ -0,0 +1,124 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/fossinvoice/fossinvoice/internal/models"
+)
+
+// ErrUnsupportedRatesFile is returned when an exchange rates file is neither ECB XML nor ECB CSV.
+var ErrUnsupportedRatesFile = errors.New("unsupported exchange rates file")
+
+// ecbEnvelope matches the eurofxref XML files: Cube > Cube[time] > Cube[currency, rate].
+type ecbEnvelope struct {
+	Cube struct {
+		Days []struct {
+			Time  string `xml:"time,attr"`
+			Rates []struct {
+				Currency string `xml:"currency,attr"`
+				Rate     string `xml:"rate,attr"`
+			} `xml:"Cube"`
+		} `xml:"Cube"`
+	} `xml:"Cube"`
+}
+
+// parseECBRates parses an ECB euro foreign exchange reference rates file, either the XML
+// (eurofxref-daily.xml, eurofxref-hist.xml) or the CSV (eurofxref.csv, eurofxref-hist.csv) format.
+// All rates are quoted against EUR.
+func parseECBRates(data []byte) ([]models.ExchangeRate, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '<' {
+		return parseECBXML(trimmed)
+	}
+	return parseECBCSV(data)
+}
+
+func parseECBXML(data []byte) ([]models.ExchangeRate, error) {
+	var env ecbEnvelope
+	if err := xml.Unmarshal(data, &env); err != nil {
+		return nil, ErrUnsupportedRatesFile
+	}
+	var rates []models.ExchangeRate
+	for _, day := range env.Cube.Days {
+		date, err := parseECBDate(day.Time)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range day.Rates {
+			rate, err := models.ParseRate(r.Rate)
+			if err != nil {
+				return nil, ErrUnsupportedRatesFile
+			}
+			rates = append(rates, ecbRate(date, r.Currency, rate))
+		}
+	}
+	if len(rates) == 0 {
+		return nil, ErrUnsupportedRatesFile
+	}
+	return rates, nil
+}
+
+// parseECBCSV parses a header row "Date, USD, JPY, ..." followed by one row per day.
+// Missing values ("N/A" or empty) are skipped.
+func parseECBCSV(data []byte) ([]models.ExchangeRate, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.TrimLeadingSpace = true
+	r.FieldsPerRecord = -1
+	rows, err := r.ReadAll()
+	if err != nil || len(rows) < 2 || !strings.EqualFold(strings.TrimSpace(rows[0][0]), "Date") {
+		return nil, ErrUnsupportedRatesFile
+	}
+	header := rows[0]
+	var rates []models.ExchangeRate
+	for _, row := range rows[1:] {
+		if len(row) == 0 || strings.TrimSpace(row[0]) == "" {
+			continue
+		}
+		date, err := parseECBDate(row[0])
+		if err != nil {
+			return nil, err
+		}
+		for i := 1; i < len(row) && i < len(header); i++ {
+			currency, value := strings.TrimSpace(header[i]), strings.TrimSpace(row[i])
+			if currency == "" || value == "" || strings.EqualFold(value, "N/A") {
+				continue
+			}
+			rate, err := models.ParseRate(value)
+			if err != nil {
+				return nil, ErrUnsupportedRatesFile
+			}
+			rates = append(rates, ecbRate(date, currency, rate))
+		}
+	}
+	if len(rates) == 0 {
+		return nil, ErrUnsupportedRatesFile
+	}
+	return rates, nil
+}
+
+// parseECBDate accepts the ISO dates of the XML and historical CSV files and the
+// "17 October 2025" form of the daily CSV file, returning an ISO date.
+func parseECBDate(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range []string{time.DateOnly, "2 January 2006"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.Format(time.DateOnly), nil
+		}
+	}
+	return "", ErrUnsupportedRatesFile
+}
+
+func ecbRate(date, currency string, rate models.Rate) models.ExchangeRate {
+	return models.ExchangeRate{
+		Date:         date,
+		BaseCurrency: "EUR",
+		Currency:     strings.ToUpper(strings.TrimSpace(currency)),
+		Rate:         rate,
+		Source:       models.RateSourceECB,
+	}
+}
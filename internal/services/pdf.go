@@ -9,6 +9,7 @@ import (
 	appdb "github.com/fossinvoice/fossinvoice/internal/db"
 	"github.com/fossinvoice/fossinvoice/internal/i18n"
 	"github.com/fossinvoice/fossinvoice/internal/models"
+	"github.com/fossinvoice/fossinvoice/internal/qrcode"
 	"github.com/go-pdf/fpdf"
 	"gorm.io/gorm"
 )
@@ -18,8 +19,11 @@ type PDFService struct{}
 // ExportInvoicePDF generates a PDF for the given invoice and writes it to outPath.
 // It will create parent directories if necessary and ensure the file has a .pdf extension.
 // ExportInvoicePDF generates a PDF for the given invoice and writes it to outPath.
-// lang is a BCP47 language tag (e.g., "en", "es-ES"). If empty, defaults to English.
-func (s *PDFService) ExportInvoicePDF(databasePath string, invoiceID uint, outPath string, lang string) error {
+// Credit notes and quotes are rendered with the same layout under their own title.
+// lang is a BCP47 language tag (e.g., "en", "es-ES"). Documents with their own Language (see
+// models.ClientDefaults) are always printed in it. If both are empty, the application language is used.
+// With embedAttachments, the files attached to the invoice are embedded in the PDF as file attachments.
+func (s *PDFService) ExportInvoicePDF(databasePath string, invoiceID uint, outPath string, lang string, embedAttachments bool) error {
 	if strings.TrimSpace(outPath) == "" {
 		return gorm.ErrInvalidData
 	}
@@ -37,7 +41,15 @@ func (s *PDFService) ExportInvoicePDF(databasePath string, invoiceID uint, outPa
 
 	// Load invoice with relations
 	var inv models.Invoice
-	if err := d.DB.Preload("Items").Preload("Company").Preload("Client").First(&inv, invoiceID).Error; err != nil {
+	if err := d.DB.Preload("Items").Preload("TaxLines").Preload("Company").Preload("Client").First(&inv, invoiceID).Error; err != nil {
+		return err
+	}
+	billTo, billToContact, err := billingDetails(d.DB, &inv)
+	if err != nil {
+		return err
+	}
+	bankAccount, err := invoiceBankAccount(d.DB, &inv)
+	if err != nil {
 		return err
 	}
 
@@ -50,12 +62,17 @@ func (s *PDFService) ExportInvoicePDF(databasePath string, invoiceID uint, outPa
 	utf8 := pdf.UnicodeTranslatorFromDescriptor("")
 
 	// i18n translator
+	if strings.TrimSpace(inv.Language) != "" {
+		lang = inv.Language
+	}
 	if strings.TrimSpace(lang) == "" {
 		if cfg, err := loadConfig(); err == nil && strings.TrimSpace(cfg.Language) != "" {
 			lang = cfg.Language
 		}
 	}
 	tr := i18n.T(lang)
+	// Invoices paid into a Swiss or Liechtenstein account get a QR-bill payment slip instead of an EPC QR code
+	qrBill := newQRBill(tr, &inv, bankAccount, billTo)
 
 	// Header: Company logo (if IconB64 present), name & address
 	x0, y0 := pdf.GetXY()
@@ -104,8 +121,9 @@ func (s *PDFService) ExportInvoicePDF(databasePath string, invoiceID uint, outPa
 
 	// Left column: Address + Tax ID
 	pdf.SetXY(left, startY)
-	if inv.Company.Address != "" {
-		pdf.MultiCell(infoColW, 5, utf8(inv.Company.Address), "", "L", false)
+	for _, line := range addressLines(tr, inv.Company.Address, billTo.Country) {
+		pdf.SetX(left)
+		pdf.CellFormat(infoColW, 5, utf8(line), "", 1, "L", false, 0, "")
 	}
 	if inv.Company.TaxID != "" {
 		pdf.SetX(left)
@@ -140,11 +158,38 @@ func (s *PDFService) ExportInvoicePDF(databasePath string, invoiceID uint, outPa
 	pdf.Ln(5)
 
 	// Invoice meta block (show only Invoice # and Date)
+	title, numberLabel := tr("pdf.invoice"), tr("pdf.invoiceNumber")
+	switch {
+	case inv.IsCreditNote():
+		title, numberLabel = tr("pdf.creditNote"), tr("pdf.creditNoteNumber")
+	case inv.IsQuote():
+		title, numberLabel = tr("pdf.quote"), tr("pdf.quoteNumber")
+	}
 	pdf.SetFont("Helvetica", "B", 12)
-	pdf.CellFormat(0, 6, utf8(tr("pdf.invoice")), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 6, utf8(title), "", 1, "L", false, 0, "")
 	pdf.SetFont("Helvetica", "", 10)
-	pdf.CellFormat(95, 5, utf8(tr("pdf.invoiceNumber")+": "+itoa(inv.Number)), "", 0, "L", false, 0, "")
+	number := documentNumber(&inv)
+	if number == "" {
+		number = tr("pdf.draft")
+	}
+	pdf.CellFormat(95, 5, utf8(numberLabel+": "+number), "", 0, "L", false, 0, "")
 	pdf.CellFormat(0, 5, utf8(tr("pdf.date")+": "+inv.IssueDate), "", 1, "L", false, 0, "")
+	if inv.IsCreditNote() && inv.OriginalInvoiceID != nil {
+		var orig models.Invoice
+		if err := d.DB.First(&orig, *inv.OriginalInvoiceID).Error; err != nil {
+			return err
+		}
+		pdf.CellFormat(0, 5, utf8(tr("pdf.creditNoteFor")+" #"+documentNumber(&orig)+" ("+orig.IssueDate+")"), "", 1, "L", false, 0, "")
+	}
+	if inv.IsQuote() && inv.ValidUntil != "" {
+		pdf.CellFormat(0, 5, utf8(tr("pdf.validUntil")+": "+inv.ValidUntil), "", 1, "L", false, 0, "")
+	}
+	if !inv.IsQuote() && !inv.IsCreditNote() && inv.DueDate != "" {
+		pdf.CellFormat(0, 5, utf8(tr("pdf.dueDate")+": "+inv.DueDate), "", 1, "L", false, 0, "")
+	}
+	if terms := paymentTermsText(tr, inv.PaymentTerms); terms != "" && !inv.IsCreditNote() {
+		pdf.CellFormat(0, 5, utf8(tr("pdf.paymentTerms")+": "+terms), "", 1, "L", false, 0, "")
+	}
 
 	// Client block
 	pdf.Ln(4)
@@ -152,19 +197,31 @@ func (s *PDFService) ExportInvoicePDF(databasePath string, invoiceID uint, outPa
 	pdf.CellFormat(0, 6, utf8(tr("pdf.billTo")), "", 1, "L", false, 0, "")
 	pdf.SetFont("Helvetica", "", 10)
 	pdf.CellFormat(0, 5, utf8(inv.Client.Name), "", 1, "L", false, 0, "")
-	if inv.Client.Address != "" {
-		pdf.MultiCell(0, 5, utf8(inv.Client.Address), "", "L", false)
+	if billToContact != nil && strings.TrimSpace(billToContact.Name) != "" {
+		pdf.CellFormat(0, 5, utf8(tr("pdf.attention")+" "+billToContact.Name), "", 1, "L", false, 0, "")
+	}
+	for _, line := range addressLines(tr, billTo, inv.Company.Address.Country) {
+		pdf.CellFormat(0, 5, utf8(line), "", 1, "L", false, 0, "")
 	}
 	if inv.Client.TaxID != "" {
 		pdf.CellFormat(0, 5, utf8(inv.Client.TaxID), "", 1, "L", false, 0, "")
 	}
+	if billToContact != nil && billToContact.Contact.Email != nil && *billToContact.Contact.Email != "" {
+		pdf.CellFormat(0, 5, utf8(*billToContact.Contact.Email), "", 1, "L", false, 0, "")
+	}
 
 	// Items table header
 	pdf.Ln(4)
 	pdf.SetFont("Helvetica", "B", 10)
-	// Columns: Description, Qty, Unit Price, Total
-	colW := []float64{95, 20, 35, 25}
-	headers := []string{tr("pdf.description"), tr("pdf.qty"), tr("pdf.unitPrice"), tr("pdf.total")}
+	// Columns: Description, Qty, Unit Price, Discount, Tax %, Total
+	colW := []float64{56, 24, 27, 23, 15, 30}
+	headers := []string{tr("pdf.description"), tr("pdf.qty"), tr("pdf.unitPrice"), tr("pdf.discountShort"), tr("pdf.taxRate"), tr("pdf.total")}
+	tableX := 15.0
+	tableW := 0.0
+	for _, w := range colW {
+		tableW += w
+	}
+	lastW := colW[len(colW)-1]
 	for i, h := range headers {
 		align := "L"
 		if i > 0 {
@@ -179,28 +236,116 @@ func (s *PDFService) ExportInvoicePDF(databasePath string, invoiceID uint, outPa
 		// Description might be long -> use MultiCell logic
 		// We'll print in a simple row assuming short descriptions for now
 		pdf.CellFormat(colW[0], 6, utf8(it.Description), "B", 0, "L", false, 0, "")
-		pdf.CellFormat(colW[1], 6, utf8(formatFloat(it.Quantity)), "B", 0, "R", false, 0, "")
-		pdf.CellFormat(colW[2], 6, utf8(formatAmount(it.UnitPrice)), "B", 0, "R", false, 0, "")
-		pdf.CellFormat(colW[3], 6, utf8(formatAmount(it.Total)), "B", 0, "R", false, 0, "")
+		pdf.CellFormat(colW[1], 6, utf8(formatQuantity(tr, it.Quantity, it.Unit)), "B", 0, "R", false, 0, "")
+		pdf.CellFormat(colW[2], 6, utf8(formatAmount(inv.Currency, it.UnitPrice)), "B", 0, "R", false, 0, "")
+		discount := ""
+		if it.DiscountRate > 0 {
+			discount = formatDecimal(it.DiscountRate) + "%"
+		} else if it.DiscountAmount != 0 {
+			discount = formatAmount(inv.Currency, it.DiscountAmount.Abs())
+		}
+		pdf.CellFormat(colW[3], 6, utf8(discount), "B", 0, "R", false, 0, "")
+		pdf.CellFormat(colW[4], 6, utf8(formatDecimal(inv.ItemTaxRate(it))+"%"), "B", 0, "R", false, 0, "")
+		pdf.CellFormat(colW[5], 6, utf8(formatAmount(inv.Currency, it.Total)), "B", 0, "R", false, 0, "")
 		pdf.Ln(-1)
 	}
 
+	// Tax summary: taxable base and tax per rate. Invoices saved before the
+	// breakdown existed fall back to a single line from the stored totals.
+	taxLines := inv.TaxLines
+	if len(taxLines) == 0 && len(inv.Items) > 0 {
+		taxLines = []models.InvoiceTaxLine{{TaxRate: inv.TaxRate, TaxableBase: inv.Subtotal, TaxAmount: inv.TaxAmount}}
+	}
+	if len(taxLines) > 0 {
+		pdf.Ln(4)
+		sumW := []float64{25, 35, 35}
+		sumX := tableX + tableW - sumW[0] - sumW[1] - sumW[2]
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.SetX(sumX)
+		pdf.CellFormat(0, 6, utf8(tr("pdf.taxSummary")), "", 1, "L", false, 0, "")
+		pdf.SetX(sumX)
+		for i, h := range []string{tr("pdf.taxRate"), tr("pdf.taxableBase"), tr("pdf.taxAmount")} {
+			pdf.CellFormat(sumW[i], 6, utf8(h), "TB", 0, "R", false, 0, "")
+		}
+		pdf.Ln(-1)
+		pdf.SetFont("Helvetica", "", 10)
+		for _, tl := range taxLines {
+			pdf.SetX(sumX)
+			pdf.CellFormat(sumW[0], 6, utf8(formatDecimal(tl.TaxRate)+"%"), "B", 0, "R", false, 0, "")
+			pdf.CellFormat(sumW[1], 6, utf8(formatAmount(inv.Currency, tl.TaxableBase)), "B", 0, "R", false, 0, "")
+			pdf.CellFormat(sumW[2], 6, utf8(formatAmount(inv.Currency, tl.TaxAmount)), "B", 0, "R", false, 0, "")
+			pdf.Ln(-1)
+		}
+	}
+
 	// Totals section
 	pdf.Ln(2)
-	rightX := 15 + colW[0] + colW[1] + colW[2]
+	rightX := tableX + tableW - lastW
 	pdf.SetXY(rightX, pdf.GetY())
-	pdf.CellFormat(colW[3], 6, utf8(tr("pdf.subtotal")+": "+formatAmount(inv.Subtotal)), "", 1, "R", false, 0, "")
+	pdf.CellFormat(lastW, 6, utf8(tr("pdf.subtotal")+": "+formatAmount(inv.Currency, inv.Subtotal)), "", 1, "R", false, 0, "")
+	if inv.DiscountAmount != 0 {
+		discountLabel := tr("pdf.discount")
+		if inv.DiscountRate > 0 {
+			discountLabel += " (" + formatDecimal(inv.DiscountRate) + "%)"
+		}
+		pdf.SetXY(rightX, pdf.GetY())
+		pdf.CellFormat(lastW, 6, utf8(discountLabel+": "+formatAmount(inv.Currency, inv.DiscountAmount.Neg())), "", 1, "R", false, 0, "")
+	}
+	taxLabel := tr("pdf.tax")
+	if len(taxLines) == 1 {
+		taxLabel += " (" + formatDecimal(taxLines[0].TaxRate) + "%)"
+	}
 	pdf.SetXY(rightX, pdf.GetY())
-	pdf.CellFormat(colW[3], 6, utf8(tr("pdf.tax")+" ("+formatFloat(inv.TaxRate)+"%): "+formatAmount(inv.TaxAmount)), "", 1, "R", false, 0, "")
-	if inv.DiscountAmount > 0 {
+	pdf.CellFormat(lastW, 6, utf8(taxLabel+": "+formatAmount(inv.Currency, inv.TaxAmount)), "", 1, "R", false, 0, "")
+	if inv.WithholdingAmount != 0 {
 		pdf.SetXY(rightX, pdf.GetY())
-		pdf.CellFormat(colW[3], 6, utf8(tr("pdf.discount")+": -"+formatAmount(inv.DiscountAmount)), "", 1, "R", false, 0, "")
+		pdf.CellFormat(lastW, 6, utf8(tr("pdf.withholding")+" ("+formatDecimal(inv.WithholdingRate)+"%): "+formatAmount(inv.Currency, inv.WithholdingAmount.Neg())), "", 1, "R", false, 0, "")
 	}
 	pdf.SetFont("Helvetica", "B", 11)
 	pdf.SetXY(rightX, pdf.GetY())
-	pdf.CellFormat(colW[3], 7, utf8(tr("pdf.grandTotal")+": "+formatMoney(inv.Currency, inv.Total)), "", 1, "R", false, 0, "")
+	pdf.CellFormat(lastW, 7, utf8(tr("pdf.grandTotal")+": "+formatMoney(inv.Currency, inv.Total)), "", 1, "R", false, 0, "")
 	pdf.SetFont("Helvetica", "", 10)
 
+	// Payment details: the bank account the invoice is paid into, with an EPC QR code for EUR invoices
+	if bankAccount != nil {
+		var epc *qrcode.Code
+		if qrBill == nil {
+			if epc, err = epcCode(&inv, bankAccount); err != nil {
+				return err
+			}
+		}
+		pdf.Ln(6)
+		textW := 0.0
+		if epc != nil {
+			// Keep the code on the same page as the details, to their right
+			pageW, pageH := pdf.GetPageSize()
+			left, _, right, _ := pdf.GetMargins()
+			_, bottom := pdf.GetAutoPageBreak()
+			if pdf.GetY()+epcQRSize+5 > pageH-bottom {
+				pdf.AddPage()
+			}
+			textW = pageW - left - right - epcQRSize - 5
+		}
+		top := pdf.GetY()
+		pdf.SetFont("Helvetica", "B", 11)
+		pdf.CellFormat(textW, 6, utf8(tr("pdf.paymentDetails")), "", 1, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 10)
+		for _, line := range paymentDetailsLines(tr, &inv, bankAccount) {
+			pdf.CellFormat(textW, 5, utf8(line), "", 1, "L", false, 0, "")
+		}
+		if epc != nil {
+			end := pdf.GetY()
+			pageW, _ := pdf.GetPageSize()
+			_, _, right, _ := pdf.GetMargins()
+			x := pageW - right - epcQRSize
+			drawQRCode(pdf, epc, x, top, epcQRSize)
+			pdf.SetFont("Helvetica", "", 8)
+			pdf.SetXY(x, top+epcQRSize)
+			pdf.CellFormat(epcQRSize, 4, utf8(tr("pdf.scanToPay")), "", 1, "C", false, 0, "")
+			pdf.SetY(max(end, pdf.GetY()))
+		}
+	}
+
 	// Invoice footer: centered text at the end of the bill (not a page footer)
 	if ft := strings.TrimSpace(inv.FooterText); ft != "" {
 		pdf.Ln(6)
@@ -209,6 +354,24 @@ func (s *PDFService) ExportInvoicePDF(databasePath string, invoiceID uint, outPa
 		pdf.MultiCell(0, 5, utf8(ft), "", "C", false)
 	}
 
+	if qrBill != nil {
+		if err := s.renderQRBill(pdf, utf8, tr, qrBill); err != nil {
+			return err
+		}
+	}
+
+	if embedAttachments {
+		attachments, err := invoiceAttachments(d.DB, inv.ID)
+		if err != nil {
+			return err
+		}
+		files := make([]fpdf.Attachment, len(attachments))
+		for i, a := range attachments {
+			files[i] = fpdf.Attachment{Content: a.Data, Filename: a.FileName, Description: a.FileName}
+		}
+		pdf.SetAttachments(files)
+	}
+
 	// Ensure directory exists
 	if err := ensureDir(filepath.Dir(outPath)); err != nil {
 		return err
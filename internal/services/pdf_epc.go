@@ -0,0 +1,95 @@
+package services
+
+import (
+	"strings"
+
+	"github.com/fossinvoice/fossinvoice/internal/models"
+	"github.com/fossinvoice/fossinvoice/internal/qrcode"
+	"github.com/go-pdf/fpdf"
+)
+
+// ==============================
+// EPC QR code ("GiroCode")
+// ==============================
+
+// epcQRSize is the printed width and height of the EPC QR code in mm (the EPC asks for at least 20).
+const epcQRSize = 30
+
+// epcMaxPayload is the largest EPC069-12 payload in bytes.
+const epcMaxPayload = 331
+
+// epcMaxAmount is the largest amount an EPC QR code can carry, EUR 999999999.99.
+var epcMaxAmount = models.NewDecimal(1_000_000_000).Sub(models.DecimalFromFloat(0.01))
+
+// epcPayload returns the EPC069-12 (version 002) payload of a SEPA credit transfer paying inv into
+// account, with the invoice number as remittance text. ok is false when the invoice cannot be paid
+// this way: it is not in EUR, the account has no IBAN, or the total is out of range.
+func epcPayload(inv *models.Invoice, account *models.BankAccount) (payload string, ok bool) {
+	total := inv.Total.RoundCurrency("EUR")
+	if !strings.EqualFold(inv.Currency, "EUR") || account.IBAN == "" || total <= 0 || total > epcMaxAmount {
+		return "", false
+	}
+	lines := []string{
+		"BCD", // service tag
+		"002", // version; the BIC is optional within the EEA
+		"1",   // character set: UTF-8
+		"SCT", // SEPA credit transfer
+		account.BIC,
+		truncateRunes(singleLine(accountHolder(inv, account)), 70),
+		models.NormalizeIBAN(account.IBAN),
+		"EUR" + total.StringFixed(2),
+		"", // purpose code
+		"", // structured creditor reference; the remittance text below is used instead
+		truncateRunes(singleLine(documentNumber(inv)), 140),
+	}
+	payload = strings.Join(lines, "\n")
+	if len(payload) > epcMaxPayload {
+		return "", false
+	}
+	return payload, true
+}
+
+// epcCode encodes the EPC QR code of an invoice, or returns nil when it has none (see epcPayload).
+func epcCode(inv *models.Invoice, account *models.BankAccount) (*qrcode.Code, error) {
+	payload, ok := epcPayload(inv, account)
+	if !ok {
+		return nil, nil
+	}
+	// The EPC guidelines require error correction level M
+	return qrcode.Encode([]byte(payload), qrcode.Medium)
+}
+
+// drawQRCode draws a QR code as filled squares with its top-left corner at x, y and the given width.
+// The quiet zone is not drawn; callers keep the area around the code blank.
+func drawQRCode(pdf *fpdf.Fpdf, code *qrcode.Code, x, y, size float64) {
+	m := size / float64(code.Size)
+	pdf.SetFillColor(0, 0, 0)
+	for row := 0; row < code.Size; row++ {
+		// One rectangle per run of dark modules keeps the content stream small
+		for col := 0; col < code.Size; {
+			if !code.Black(col, row) {
+				col++
+				continue
+			}
+			start := col
+			for col < code.Size && code.Black(col, row) {
+				col++
+			}
+			pdf.Rect(x+float64(start)*m, y+float64(row)*m, float64(col-start)*m, m, "F")
+		}
+	}
+}
+
+// singleLine joins the lines of s with spaces, since payment payloads are line based.
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// truncateRunes shortens s to at most n characters.
+func truncateRunes(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n])
+}
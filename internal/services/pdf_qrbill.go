@@ -0,0 +1,329 @@
+package services
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/fossinvoice/fossinvoice/internal/models"
+	"github.com/fossinvoice/fossinvoice/internal/qrcode"
+	"github.com/go-pdf/fpdf"
+)
+
+// ==============================
+// Swiss QR-bill
+// ==============================
+
+// Swiss QR-bill layout in mm (Swiss Payment Standards, style guide for the QR-bill): an A6 landscape
+// payment slip across the bottom of an A4 page, made of the receipt on the left and the payment
+// part on the right.
+const (
+	qrBillHeight       = 105
+	qrBillReceiptWidth = 62
+	qrBillMargin       = 5
+	qrBillQRSize       = 46
+	qrBillCrossSize    = 7
+)
+
+// qrBillParty is the creditor or debtor of a QR-bill, always sent as a structured address ("S").
+type qrBillParty struct {
+	Name    string
+	Address models.PostalAddress
+}
+
+// qrBill holds the data of a Swiss QR-bill payment part.
+type qrBill struct {
+	IBAN          string // normalized CH or LI IBAN; a QR-IBAN requires a QR reference
+	Creditor      qrBillParty
+	Amount        models.Decimal
+	Currency      string       // CHF or EUR
+	Debtor        *qrBillParty // nil when the billing address is incomplete; a blank box is printed instead
+	ReferenceType string       // QRR (QR reference) or NON
+	Reference     string
+	Message       string // unstructured message, printed as additional information
+}
+
+// newQRBill returns the QR-bill of an invoice paid into account, or nil when the invoice cannot be
+// paid with one: the account is not a Swiss or Liechtenstein IBAN, the currency is not CHF or EUR, the
+// total is out of range, or the company address lacks its postal code or town.
+// Invoices paid into a QR-IBAN get a QR reference derived from the invoice ID.
+func newQRBill(tr func(string) string, inv *models.Invoice, account *models.BankAccount, billTo models.PostalAddress) *qrBill {
+	if account == nil {
+		return nil
+	}
+	iban := models.NormalizeIBAN(account.IBAN)
+	currency := strings.ToUpper(inv.Currency)
+	total := inv.Total.RoundCurrency(currency)
+	if !strings.HasPrefix(iban, "CH") && !strings.HasPrefix(iban, "LI") {
+		return nil
+	}
+	if (currency != "CHF" && currency != "EUR") || total <= 0 || total > epcMaxAmount {
+		return nil
+	}
+
+	creditor := qrBillParty{Name: accountHolder(inv, account), Address: inv.Company.Address}
+	if creditor.Address.Country == "" {
+		creditor.Address.Country = iban[:2]
+	}
+	if !creditor.complete() {
+		return nil
+	}
+	bill := &qrBill{
+		IBAN:          iban,
+		Creditor:      creditor,
+		Amount:        total,
+		Currency:      currency,
+		ReferenceType: "NON",
+		Message:       tr("pdf.invoice") + " " + documentNumber(inv),
+	}
+	debtor := qrBillParty{Name: inv.Client.Name, Address: billTo}
+	if debtor.Address.Country == "" {
+		debtor.Address.Country = creditor.Address.Country
+	}
+	if debtor.complete() {
+		bill.Debtor = &debtor
+	}
+	if isQRIBAN(iban) {
+		bill.ReferenceType = "QRR"
+		bill.Reference = qrReference(strconv.FormatUint(uint64(inv.ID), 10))
+	}
+	return bill
+}
+
+// complete reports whether the party has the name, postal code, town and country a structured address needs.
+func (p qrBillParty) complete() bool {
+	return strings.TrimSpace(p.Name) != "" && strings.TrimSpace(p.Address.PostalCode) != "" &&
+		strings.TrimSpace(p.Address.City) != "" && len(strings.TrimSpace(p.Address.Country)) == 2
+}
+
+// street returns the first address line, which holds street and number.
+func (p qrBillParty) street() string {
+	line, _, _ := strings.Cut(p.Address.Line1, "\n")
+	return strings.TrimSpace(line)
+}
+
+// fields returns the seven payload elements of a structured address: type, name, street, building
+// number (kept in the street), postal code, town and country.
+func (p qrBillParty) fields() []string {
+	a := p.Address
+	return []string{
+		"S",
+		truncateRunes(singleLine(p.Name), 70),
+		truncateRunes(p.street(), 70),
+		"",
+		truncateRunes(strings.TrimSpace(a.PostalCode), 16),
+		truncateRunes(strings.TrimSpace(a.City), 35),
+		strings.ToUpper(strings.TrimSpace(a.Country)),
+	}
+}
+
+// lines returns the party as printed on the slip: name, street and locality. The country code
+// prefixes the postal code for addresses outside Switzerland and Liechtenstein.
+func (p qrBillParty) lines() []string {
+	locality := strings.TrimSpace(p.Address.PostalCode) + " " + strings.TrimSpace(p.Address.City)
+	if country := strings.ToUpper(p.Address.Country); country != "CH" && country != "LI" {
+		locality = country + "-" + locality
+	}
+	var lines []string
+	for _, l := range []string{singleLine(p.Name), p.street(), locality} {
+		if l != "" {
+			lines = append(lines, l)
+		}
+	}
+	return lines
+}
+
+// payload returns the Swiss Payment Code ("SPC", version 2.0) encoded in the QR code.
+func (b *qrBill) payload() string {
+	fields := []string{"SPC", "0200", "1", b.IBAN}
+	fields = append(fields, b.Creditor.fields()...)
+	fields = append(fields, "", "", "", "", "", "", "") // ultimate creditor, reserved for future use
+	fields = append(fields, b.Amount.StringFixed(2), b.Currency)
+	if b.Debtor != nil {
+		fields = append(fields, b.Debtor.fields()...)
+	} else {
+		fields = append(fields, "", "", "", "", "", "", "")
+	}
+	fields = append(fields, b.ReferenceType, b.Reference, truncateRunes(singleLine(b.Message), 140), "EPD")
+	return strings.Join(fields, "\n")
+}
+
+// isQRIBAN reports whether a Swiss or Liechtenstein IBAN is a QR-IBAN, whose institution ID
+// (positions 5 to 9) is in the range 30000-31999.
+func isQRIBAN(iban string) bool {
+	if len(iban) < 9 || (!strings.HasPrefix(iban, "CH") && !strings.HasPrefix(iban, "LI")) {
+		return false
+	}
+	iid, err := strconv.Atoi(iban[4:9])
+	return err == nil && iid >= 30000 && iid <= 31999
+}
+
+// qrReference returns the 27-digit QR reference for a number of up to 26 digits: the number padded
+// with leading zeros, followed by its mod-10 recursive check digit.
+func qrReference(number string) string {
+	if len(number) < 26 {
+		number = strings.Repeat("0", 26-len(number)) + number
+	}
+	return number + strconv.Itoa(mod10Recursive(number))
+}
+
+// mod10Recursive returns the check digit of a string of digits, computed with the modulo 10
+// recursive algorithm used by Swiss payment references.
+func mod10Recursive(digits string) int {
+	table := [10]int{0, 9, 4, 6, 8, 2, 7, 1, 3, 5}
+	carry := 0
+	for _, c := range digits {
+		carry = table[(carry+int(c-'0'))%10]
+	}
+	return (10 - carry) % 10
+}
+
+// formatQRReference prints a QR reference in blocks of five digits from the right, e.g. "21 00000 00003 13947 14300 09017".
+func formatQRReference(ref string) string {
+	var blocks []string
+	for len(ref) > 5 {
+		blocks = append([]string{ref[len(ref)-5:]}, blocks...)
+		ref = ref[:len(ref)-5]
+	}
+	return strings.Join(append([]string{ref}, blocks...), " ")
+}
+
+// formatQRBillAmount prints an amount with two decimals and spaces between thousands, e.g. "1 949.75".
+func formatQRBillAmount(v models.Decimal) string {
+	s := v.StringFixed(2)
+	intPart, frac, _ := strings.Cut(s, ".")
+	var b strings.Builder
+	for i, c := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteRune(c)
+	}
+	return b.String() + "." + frac
+}
+
+// renderQRBill draws the payment slip of a QR-bill across the bottom of the last page, adding a
+// page when the invoice content reaches into it. Slip labels are printed in the document language
+// when it is one of the languages the standard allows, and in English otherwise.
+func (s *PDFService) renderQRBill(pdf *fpdf.Fpdf, utf8 func(string) string, tr func(string) string, bill *qrBill) error {
+	code, err := qrcode.Encode([]byte(bill.payload()), qrcode.Medium)
+	if err != nil {
+		return err
+	}
+
+	pageW, pageH := pdf.GetPageSize()
+	top := pageH - qrBillHeight
+	if pdf.GetY() > top-qrBillMargin {
+		pdf.AddPage()
+	}
+	autoBreak, breakMargin := pdf.GetAutoPageBreak()
+	pdf.SetAutoPageBreak(false, 0)
+	defer pdf.SetAutoPageBreak(autoBreak, breakMargin)
+
+	// Dashed separation lines above the slip and between receipt and payment part
+	pdf.SetDrawColor(0, 0, 0)
+	pdf.SetLineWidth(0.2)
+	pdf.SetDashPattern([]float64{1, 1}, 0)
+	pdf.Line(0, top, pageW, top)
+	pdf.Line(qrBillReceiptWidth, top, qrBillReceiptWidth, pageH)
+	pdf.SetDashPattern([]float64{}, 0)
+
+	text := func(x, y, w float64, size float64, bold bool, align, s string) {
+		style := ""
+		if bold {
+			style = "B"
+		}
+		pdf.SetFont("Helvetica", style, size)
+		pdf.SetXY(x, y)
+		pdf.CellFormat(w, size*0.3528+0.5, utf8(s), "", 0, align, false, 0, "")
+	}
+	// section prints a heading and its lines, and returns the y after them and a blank line
+	section := func(x, y, w, headSize, valueSize, lineH float64, heading string, lines []string) float64 {
+		text(x, y, w, headSize, true, "L", heading)
+		y += lineH
+		for _, l := range lines {
+			text(x, y, w, valueSize, false, "L", l)
+			y += lineH
+		}
+		return y + lineH*0.75
+	}
+	account := append([]string{models.FormatIBAN(bill.IBAN)}, bill.Creditor.lines()...)
+	reference := ""
+	if bill.Reference != "" {
+		reference = formatQRReference(bill.Reference)
+	}
+
+	// Receipt
+	x, w := float64(qrBillMargin), float64(qrBillReceiptWidth-2*qrBillMargin)
+	text(x, top+qrBillMargin, w, 11, true, "L", tr("qrbill.receipt"))
+	y := top + 12
+	y = section(x, y, w, 6, 8, 3.2, tr("qrbill.account"), account)
+	if reference != "" {
+		y = section(x, y, w, 6, 8, 3.2, tr("qrbill.reference"), []string{reference})
+	}
+	if bill.Debtor != nil {
+		section(x, y, w, 6, 8, 3.2, tr("qrbill.payableBy"), bill.Debtor.lines())
+	} else {
+		text(x, y, w, 6, true, "L", tr("qrbill.payableByNameAddress"))
+		drawCornerMarks(pdf, x, y+3.2, 52, 20)
+	}
+	text(x, top+68, 12, 6, true, "L", tr("qrbill.currency"))
+	text(x+13, top+68, w-13, 6, true, "L", tr("qrbill.amount"))
+	text(x, top+71.5, 12, 8, false, "L", bill.Currency)
+	text(x+13, top+71.5, w-13, 8, false, "L", formatQRBillAmount(bill.Amount))
+	text(x, top+82, w, 6, true, "R", tr("qrbill.acceptancePoint"))
+
+	// Payment part: title, Swiss QR code and amount on the left, details on the right
+	x = qrBillReceiptWidth + qrBillMargin
+	text(x, top+qrBillMargin, 51, 11, true, "L", tr("qrbill.paymentPart"))
+	qrY := top + 17
+	drawQRCode(pdf, code, x, qrY, qrBillQRSize)
+	drawSwissCross(pdf, x+qrBillQRSize/2, qrY+qrBillQRSize/2)
+	text(x, top+68, 14, 8, true, "L", tr("qrbill.currency"))
+	text(x+14, top+68, 37, 8, true, "L", tr("qrbill.amount"))
+	text(x, top+72.5, 14, 10, false, "L", bill.Currency)
+	text(x+14, top+72.5, 37, 10, false, "L", formatQRBillAmount(bill.Amount))
+
+	x, w = qrBillReceiptWidth+56, pageW-qrBillReceiptWidth-56-qrBillMargin
+	y = top + qrBillMargin
+	y = section(x, y, w, 8, 10, 4, tr("qrbill.account"), account)
+	if reference != "" {
+		y = section(x, y, w, 8, 10, 4, tr("qrbill.reference"), []string{reference})
+	}
+	if bill.Message != "" {
+		y = section(x, y, w, 8, 10, 4, tr("qrbill.additionalInformation"), []string{bill.Message})
+	}
+	if bill.Debtor != nil {
+		section(x, y, w, 8, 10, 4, tr("qrbill.payableBy"), bill.Debtor.lines())
+	} else {
+		text(x, y, w, 8, true, "L", tr("qrbill.payableByNameAddress"))
+		drawCornerMarks(pdf, x, y+4, 65, 25)
+	}
+	return nil
+}
+
+// drawSwissCross draws the Swiss cross in the centre of the QR code: a black square with a white
+// border and a white cross, 7 mm wide.
+func drawSwissCross(pdf *fpdf.Fpdf, cx, cy float64) {
+	half := qrBillCrossSize / 2.0
+	pdf.SetFillColor(255, 255, 255)
+	pdf.Rect(cx-half, cy-half, qrBillCrossSize, qrBillCrossSize, "F")
+	pdf.SetFillColor(0, 0, 0)
+	pdf.Rect(cx-half+0.5, cy-half+0.5, qrBillCrossSize-1, qrBillCrossSize-1, "F")
+	// Arms in the proportions of the flag: 6/32 of the square wide, 20/32 long
+	arm, length := (qrBillCrossSize-1)*6/32.0, (qrBillCrossSize-1)*20/32.0
+	pdf.SetFillColor(255, 255, 255)
+	pdf.Rect(cx-arm/2, cy-length/2, arm, length, "F")
+	pdf.Rect(cx-length/2, cy-arm/2, length, arm, "F")
+	pdf.SetFillColor(0, 0, 0)
+}
+
+// drawCornerMarks draws the corners of a blank box for data filled in by hand.
+func drawCornerMarks(pdf *fpdf.Fpdf, x, y, w, h float64) {
+	const l = 3
+	pdf.SetLineWidth(0.26)
+	for _, c := range [][4]float64{{x, y, 1, 1}, {x + w, y, -1, 1}, {x, y + h, 1, -1}, {x + w, y + h, -1, -1}} {
+		pdf.Line(c[0], c[1], c[0]+c[2]*l, c[1])
+		pdf.Line(c[0], c[1], c[0], c[1]+c[3]*l)
+	}
+	pdf.SetLineWidth(0.2)
+}
@@ -1,28 +1,158 @@
 package services
 
 import (
+	"errors"
+	"mime"
+	"net/http"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
+
+	"github.com/fossinvoice/fossinvoice/internal/models"
 )
 
+// maxStoredFileSize is the largest file stored in the database as a receipt or attachment (20 MB).
+const maxStoredFileSize = 20 << 20
+
+// ErrFileTooLarge is returned when a receipt or attachment exceeds 20 MB.
+var ErrFileTooLarge = errors.New("file too large")
+
 func itoa(n int) string { return strconv.Itoa(n) }
 
-func formatFloat(v float64) string {
-	return strconv.FormatFloat(v, 'f', -1, 64)
+// readStoredFile reads a file to store in the database and returns its content and MIME type, taken
+// from the file extension or sniffed from the content when unknown.
+func readStoredFile(filePath string) ([]byte, string, error) {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return nil, "", err
+	}
+	if info.Size() > maxStoredFileSize {
+		return nil, "", ErrFileTooLarge
+	}
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, "", err
+	}
+	mimeType := mime.TypeByExtension(strings.ToLower(filepath.Ext(filePath)))
+	if mimeType == "" {
+		mimeType = http.DetectContentType(data)
+	}
+	return data, mimeType, nil
+}
+
+// documentNumber returns the number printed on a document, falling back to the plain sequence number.
+// Drafts that have not been numbered yet return "".
+func documentNumber(inv *models.Invoice) string {
+	if inv.DisplayNumber != "" || inv.Number == 0 {
+		return inv.DisplayNumber
+	}
+	return itoa(inv.Number)
+}
+
+// formatDecimal returns the shortest representation of a quantity or rate (e.g. "1.5", "21").
+func formatDecimal(v models.Decimal) string {
+	return v.String()
+}
+
+// formatQuantity returns a quantity followed by its localized unit label, e.g. "3 h" or "2 días".
+// Unknown unit codes are printed as is.
+func formatQuantity(tr func(string) string, qty models.Decimal, unit string) string {
+	s := formatDecimal(qty)
+	if unit == "" {
+		return s
+	}
+	form := "other"
+	if qty.Abs() == models.NewDecimal(1) {
+		form = "one"
+	}
+	key := "units." + unit + "." + form
+	label := tr(key)
+	if label == key {
+		label = unit
+	}
+	return s + " " + label
+}
+
+// addressLines returns the printed lines of a postal address, formatted for its country. The
+// localized country name is added as last line when it differs from the other party's country
+// (homeCountry), as international mail requires.
+func addressLines(tr func(string) string, a models.PostalAddress, homeCountry string) []string {
+	lines := a.Lines()
+	country := strings.ToUpper(strings.TrimSpace(a.Country))
+	if country != "" && !strings.EqualFold(country, strings.TrimSpace(homeCountry)) {
+		key := "countries." + country
+		name := tr(key)
+		if name == key {
+			name = country
+		}
+		lines = append(lines, name)
+	}
+	return lines
 }
 
-func formatMoney(currency string, v float64) string {
-	s := strconv.FormatFloat(v, 'f', 2, 64)
+// paymentTermsText returns the localized description of payment terms, e.g. "Net 30 days", or "" when none are set.
+func paymentTermsText(tr func(string) string, t models.PaymentTerms) string {
+	key := ""
+	switch {
+	case t.Type == models.PaymentTermsNet:
+		key = "pdf.termsNet"
+	case t.Type == models.PaymentTermsEndOfMonth && t.Days > 0:
+		key = "pdf.termsEndOfMonthPlus"
+	case t.Type == models.PaymentTermsEndOfMonth:
+		key = "pdf.termsEndOfMonth"
+	case t.Type == models.PaymentTermsOnReceipt:
+		key = "pdf.termsOnReceipt"
+	default:
+		return ""
+	}
+	return strings.ReplaceAll(tr(key), "{days}", itoa(t.Days))
+}
+
+// paymentDetailsLines returns the lines of the "Payment details" block: beneficiary, bank, IBAN, BIC,
+// free-form details and the invoice number as payment reference.
+func paymentDetailsLines(tr func(string) string, inv *models.Invoice, a *models.BankAccount) []string {
+	lines := []string{tr("pdf.accountHolder") + ": " + accountHolder(inv, a)}
+	if a.BankName != "" {
+		lines = append(lines, tr("pdf.bank")+": "+a.BankName)
+	}
+	if a.IBAN != "" {
+		lines = append(lines, "IBAN: "+models.FormatIBAN(a.IBAN))
+	}
+	if a.BIC != "" {
+		lines = append(lines, "BIC: "+a.BIC)
+	}
+	for _, l := range strings.Split(a.Details, "\n") {
+		if l = strings.TrimSpace(l); l != "" {
+			lines = append(lines, l)
+		}
+	}
+	return append(lines, tr("pdf.paymentReference")+": "+documentNumber(inv))
+}
+
+// accountHolder returns the beneficiary of a bank account, which defaults to the invoicing company.
+func accountHolder(inv *models.Invoice, a *models.BankAccount) string {
+	if holder := strings.TrimSpace(a.AccountHolder); holder != "" {
+		return holder
+	}
+	return strings.TrimSpace(inv.Company.Name)
+}
+
+func formatMoney(currency string, v models.Decimal) string {
+	s := formatAmount(currency, v)
 	if strings.TrimSpace(currency) == "" {
 		return s
 	}
 	return currency + " " + s
 }
 
-// formatAmount returns a numeric amount with 2 decimal places and no currency symbol.
-func formatAmount(v float64) string {
-	return strconv.FormatFloat(v, 'f', 2, 64)
+// formatAmount returns a numeric amount with the currency's minor-unit digits and no currency symbol.
+// Amounts carrying more precision than the currency (e.g. unit prices of 0.0125) are printed in full.
+func formatAmount(currency string, v models.Decimal) string {
+	if v.RoundCurrency(currency) != v {
+		return v.String()
+	}
+	return v.StringFixed(models.CurrencyDecimals(currency))
 }
 
 func ensureDir(dir string) error {